@@ -0,0 +1,203 @@
+package hexdump
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"iter"
+)
+
+const (
+	diffChunk  = 4096    // The number of bytes compared at a time.
+	anchorSize = 8       // The number of identical bytes which resynchronize the inputs.
+	minResync  = 64      // The initial number of bytes searched for an anchor.
+	maxResync  = 1 << 16 // The maximum number of bytes searched for an anchor, which bounds the memory of a diff.
+	hashBase   = 0x100000001b3
+)
+
+// hunk is a run of the edit script from the old input to the new input,
+// the bytes of an equal hunk are the same in both inputs, otherwise the old bytes are replaced with the new bytes,
+// and either of them may be empty if the bytes are inserted or deleted.
+type hunk struct {
+	old, new []byte
+	equal    bool
+}
+
+// editScript reads the inputs and calls f with the hunks of the edit script in order,
+// the bytes of the hunks are only valid during the call.
+//
+// The bytes at the same offsets of the inputs are compared unless align is set,
+// otherwise the inputs are resynchronized after the differing bytes at the nearest anchor,
+// which is a run of identical bytes in the next [maxResync] bytes of both inputs.
+// The differing bytes without any anchor are replaced at the same offsets.
+func editScript(a, b io.Reader, align bool, f func(h hunk) error) error {
+	ra, rb := bufio.NewReaderSize(a, maxResync+anchorSize), bufio.NewReaderSize(b, maxResync+anchorSize)
+
+	for {
+		pa, err := peek(ra, diffChunk)
+		if err != nil {
+			return err
+		}
+
+		pb, err := peek(rb, diffChunk)
+		if err != nil {
+			return err
+		}
+
+		var h hunk
+
+		switch n := commonPrefix(pa, pb); {
+		case len(pa) == 0 && len(pb) == 0:
+			return nil
+
+		case n > 0:
+			h = hunk{pa[:n], pb[:n], true}
+
+		case len(pa) == 0 || len(pb) == 0:
+			h = hunk{old: pa, new: pb}
+
+		case align:
+			i, j, err := resync(ra, rb)
+			if err != nil {
+				return err
+			}
+
+			pa, _ = ra.Peek(i)
+			pb, _ = rb.Peek(j)
+			h = hunk{old: pa, new: pb}
+
+		default:
+			n = differingPrefix(pa, pb)
+			h = hunk{old: pa[:n], new: pb[:n]}
+		}
+
+		if err = f(h); err != nil {
+			return err
+		}
+
+		_, _ = ra.Discard(len(h.old))
+		_, _ = rb.Discard(len(h.new))
+	}
+}
+
+// peek returns the next n bytes of the reader without advancing it, or less at the end of the input.
+func peek(r *bufio.Reader, n int) ([]byte, error) {
+	b, err := r.Peek(n)
+	if errors.Is(err, io.EOF) {
+		err = nil
+	}
+
+	return b, err
+}
+
+func commonPrefix(a, b []byte) (n int) {
+	for n < len(a) && n < len(b) && a[n] == b[n] {
+		n++
+	}
+
+	return
+}
+
+func differingPrefix(a, b []byte) (n int) {
+	for n < len(a) && n < len(b) && a[n] != b[n] {
+		n++
+	}
+
+	return
+}
+
+// resync returns the number of differing bytes of the inputs before the nearest anchor,
+// the search window doubles until an anchor is found, the end of both inputs or [maxResync] is reached.
+func resync(ra, rb *bufio.Reader) (int, int, error) {
+	for n := minResync; ; n = min(n*2, maxResync) {
+		a, err := peek(ra, n+anchorSize)
+		if err != nil {
+			return 0, 0, err
+		}
+
+		b, err := peek(rb, n+anchorSize)
+		if err != nil {
+			return 0, 0, err
+		}
+
+		if i, j, ok := anchor(a, b); ok {
+			return i, j, nil
+		}
+
+		if len(a) < n+anchorSize && len(b) < n+anchorSize {
+			return len(a), len(b), nil
+		}
+
+		if n == maxResync {
+			n = min(n, len(a), len(b))
+
+			return n, n, nil
+		}
+	}
+}
+
+// anchor returns the offsets of the nearest run of identical bytes of the inputs,
+// which has the least sum of offsets and then the least difference of offsets.
+//
+// The run is shorter than [anchorSize] only at the end of the inputs.
+func anchor(a, b []byte) (i, j int, ok bool) {
+	k := min(anchorSize, len(a), len(b))
+	if k == 0 {
+		return
+	}
+
+	first := make(map[uint64]int)
+
+	for y, h := range rollingHash(b, k) {
+		if _, dup := first[h]; !dup {
+			first[h] = y
+		}
+	}
+
+	for x, h := range rollingHash(a, k) {
+		if ok && x > i+j {
+			break
+		}
+
+		y, found := first[h]
+		if !found || !bytes.Equal(a[x:x+k], b[y:y+k]) {
+			continue
+		}
+
+		if !ok || x+y < i+j || x+y == i+j && abs(x-y) < abs(i-j) {
+			i, j, ok = x, y, true
+		}
+	}
+
+	return
+}
+
+// rollingHash yields the offset and hash of each run of k bytes.
+func rollingHash(b []byte, k int) iter.Seq2[int, uint64] {
+	return func(yield func(int, uint64) bool) {
+		var h uint64
+
+		pow := uint64(1)
+
+		for i := range k {
+			h = h*hashBase + uint64(b[i])
+
+			if i > 0 {
+				pow *= hashBase
+			}
+		}
+
+		for i := 0; yield(i, h) && i+k < len(b); i++ {
+			h = (h-uint64(b[i])*pow)*hashBase + uint64(b[i+k])
+		}
+	}
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+
+	return n
+}
@@ -0,0 +1,71 @@
+package hexdump
+
+import (
+	"encoding/binary"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// BinaryStyle is a [DisplayStyle] which displays each byte as eight binary digits.
+type BinaryStyle struct {
+	// Separate the high and low nibbles with an underscore, like 0100_1000.
+	Nibbles bool
+
+	// Display the least significant bit first.
+	LSBFirst bool
+}
+
+// StyleOneByteBinary is the one-byte binary display with the most significant bit first.
+var StyleOneByteBinary DisplayStyle = BinaryStyle{}
+
+// GroupSize returns the number of bytes rendered in one cell.
+func (s BinaryStyle) GroupSize() int { return 1 }
+
+// CellWidth returns the width of a rendered cell.
+func (s BinaryStyle) CellWidth() int {
+	if s.Nibbles {
+		return 9
+	}
+
+	return 8
+}
+
+// FormatGroup renders a group of bytes as a cell.
+func (s BinaryStyle) FormatGroup(b []byte, _ binary.ByteOrder) string {
+	var sb strings.Builder
+
+	for i := range 8 {
+		if s.Nibbles && i == 4 {
+			sb.WriteByte('_')
+		}
+
+		bit := 7 - i
+		if s.LSBFirst {
+			bit = i
+		}
+
+		sb.WriteByte('0' + b[0]>>bit&1)
+	}
+
+	return sb.String()
+}
+
+// ParseGroup parses a cell back into a group of n bytes.
+func (s BinaryStyle) ParseGroup(cell string, _ int, _ binary.ByteOrder) ([]byte, error) {
+	digits := []byte(strings.ReplaceAll(strings.TrimSpace(cell), "_", ""))
+
+	if s.LSBFirst {
+		slices.Reverse(digits)
+	}
+
+	v, err := strconv.ParseUint(string(digits), 2, 8)
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte{byte(v)}, nil
+}
+
+// PaddingCell returns the text of a cell without content.
+func (s BinaryStyle) PaddingCell() string { return spaces(s.CellWidth()) }
@@ -0,0 +1,82 @@
+package hexdump
+
+import (
+	"slices"
+	"strings"
+)
+
+// DefaultGroupCells is the default number of cells in a group of the content column.
+const DefaultGroupCells = 8
+
+// CellFormat is the format of the cells in the content column and the delimiters of the char column.
+type CellFormat struct {
+	Group    int       // The number of bytes in a group, the default is [DefaultGroupCells] cells.
+	CellSep  string    // The separator between cells, the default is a space.
+	GroupSep string    // The separator between groups, the default is the cell separator followed by a space.
+	NoSep    bool      // Display the cells without separators, like "48656c6c".
+	Upper    bool      // Display the digits of the hexadecimal styles in upper case.
+	Delims   [2]string // The delimiters of the char column, the default is "|" on both sides.
+	NoDelims bool      // Display the char column without delimiters.
+}
+
+// hexStyles are the display styles whose digits are displayed in upper case with [CellFormat.Upper].
+var hexStyles = []DisplayStyle{StyleCanonical, StyleOneByteHex, StyleTwoBytesHex, StyleFourBytesHex, StyleEightBytesHex}
+
+// groupBytes returns the number of bytes in a group of cells of the style.
+func (c CellFormat) groupBytes(s DisplayStyle) int {
+	if c.Group > 0 {
+		return c.Group
+	}
+
+	return DefaultGroupCells * s.GroupSize()
+}
+
+// separator returns the separator before the cell at the position of the line, which is not the first cell.
+func (c CellFormat) separator(s DisplayStyle, pos int) string {
+	if c.NoSep {
+		return ""
+	}
+
+	sep := c.CellSep
+	if sep == "" {
+		sep = " "
+	}
+
+	if pos%c.groupBytes(s) != 0 {
+		return sep
+	}
+
+	if c.GroupSep != "" {
+		return c.GroupSep
+	}
+
+	return sep + " "
+}
+
+// delims returns the left and right delimiters of the char column.
+func (c CellFormat) delims() (left, right string) {
+	if c.NoDelims {
+		return "", ""
+	}
+
+	left, right = c.Delims[0], c.Delims[1]
+
+	if left == "" {
+		left = "|"
+	}
+
+	if right == "" {
+		right = "|"
+	}
+
+	return
+}
+
+// text returns the text of a cell rendered by the style.
+func (c CellFormat) text(s DisplayStyle, cell string) string {
+	if c.Upper && slices.Contains(hexStyles, s) {
+		return strings.ToUpper(cell)
+	}
+
+	return cell
+}
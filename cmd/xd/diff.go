@@ -0,0 +1,63 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+
+	. "github.com/flier/hexdump" //nolint:revive,stylecheck
+)
+
+// diff compares two files side by side, and exits with status 1 if they differ, or 2 on trouble like cmp(1).
+func diff(args []string) error {
+	fs := flag.NewFlagSet("xd diff", flag.ExitOnError)
+
+	width := fs.Int("w", 0, fmt.Sprintf("number of bytes per line of each file (default %d)", DefaultDiffWidth))
+	skip := fs.Int64("s", 0, "skip first skip bytes of both files")
+	length := fs.Int64("n", 0, "compare only length bytes of both files")
+	verbose := fs.Bool("v", false, "display all lines without squeezing identical lines")
+	align := fs.Bool("a", false, "align the files so inserted and deleted bytes are displayed as gaps")
+	color := ColorAuto
+
+	fs.TextVar(&color, "L", color, "color mode of the diff")
+	fs.Func("theme", "color theme by name ("+strings.Join(ThemeNames(), ", ")+") or theme file", setTheme)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: xd diff [flags] FILE1 FILE2")
+		fs.PrintDefaults()
+	}
+
+	_ = fs.Parse(args)
+
+	if fs.NArg() != 2 {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	opts := []Option{Color(color), LineWidth(*width), Skip(*skip), Length(*length), Verbose(*verbose), Align(*align)}
+	if theme != nil {
+		opts = append(opts, Theme(theme))
+	}
+
+	var changes []Change
+
+	err := withInput(fs.Arg(0), func(a io.Reader) error {
+		return withInput(fs.Arg(1), func(b io.Reader) (err error) {
+			changes, err = Diff(a, b, opts...)
+
+			return
+		})
+	})
+	if err != nil {
+		slog.Error("diff", "err", err)
+		os.Exit(2)
+	}
+
+	if len(changes) > 0 {
+		os.Exit(1)
+	}
+
+	return nil
+}
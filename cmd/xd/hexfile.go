@@ -0,0 +1,98 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+
+	. "github.com/flier/hexdump" //nolint:revive,stylecheck
+)
+
+// subcommands converts between binary content and the record files of microcontroller programmers,
+// or compares two files side by side.
+var subcommands = map[string]func(args []string) error{
+	"ihex": ihex,
+	"srec": srec,
+	"diff": diff,
+}
+
+func ihex(args []string) error {
+	return hexFile("ihex", args, ModeIHex, DecodeIHex)
+}
+
+func srec(args []string) error {
+	return hexFile("srec", args, ModeS19, DecodeSRec)
+}
+
+// hexFile encodes the binary content of the files into the records of the mode,
+// or decodes the records and dumps the memory image with the -d flag.
+func hexFile(name string, args []string, mode OutputMode, decode func(io.Reader) (*Image, error)) error {
+	fs := flag.NewFlagSet("xd "+name, flag.ExitOnError)
+
+	decoding := fs.Bool("d", false, "decode the records and dump the memory image")
+	addr := fs.Int64("a", 0, "load address of the binary content")
+	width := fs.Int("w", 0, "number of data bytes per record or dump line (default 16)")
+	color := ColorAuto
+
+	fs.TextVar(&color, "L", color, "color mode of the dump")
+
+	if mode != ModeIHex {
+		fs.TextVar(&mode, "t", mode, "record type (s19, s28, s37)")
+	}
+
+	_ = fs.Parse(args)
+
+	if mode != ModeIHex && mode != ModeS19 && mode != ModeS28 && mode != ModeS37 {
+		return fmt.Errorf("record type %s, %w", mode, os.ErrInvalid)
+	}
+
+	files := fs.Args()
+	if len(files) == 0 {
+		files = []string{"-"}
+	}
+
+	for _, file := range files {
+		var err error
+
+		if *decoding {
+			err = withInput(file, func(r io.Reader) error {
+				m, err := decode(r)
+				if err != nil {
+					return fmt.Errorf("decode %s, %w", file, err)
+				}
+
+				slog.Debug("decode records", "name", file, "segments", len(m.Segments), "size", m.Size(), "entry", m.Entry)
+
+				return m.Dump(Color(color), LineWidth(*width))
+			})
+		} else {
+			err = withInput(file, func(r io.Reader) error {
+				return Stream(r, Mode(mode), Start(*addr), LineWidth(*width), Name(file))
+			})
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// withInput calls f with the content of the file, or the standard input if the name is "-".
+func withInput(name string, f func(r io.Reader) error) error {
+	if name == "-" {
+		return f(os.Stdin)
+	}
+
+	file, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+
+	defer file.Close()
+
+	return f(file)
+}
@@ -2,38 +2,144 @@ package main
 
 import (
 	"flag"
+	"fmt"
 	"io"
 	"log/slog"
 	"os"
+	"strconv"
+	"strings"
 
 	. "github.com/flier/hexdump" //nolint:revive,stylecheck
 )
 
 var (
-	canonical    = flag.Bool("C", false, "canonical hex+ASCII display")
-	oneByteOctal = flag.Bool("b", false, "one-byte octal")
-	oneByteHex   = flag.Bool("X", false, "one-byte hex")
-	oneByteChar  = flag.Bool("c", false, "one-byte char")
-	twoBytesDec  = flag.Bool("d", false, "two-byte decimal")
-	twoBytesOct  = flag.Bool("e", false, "two-byte octal")
-	twoBytesHex  = flag.Bool("x", false, "two-byte hex")
-	color        = ColorAuto
-	noColor      = flag.Bool("no-color", false, "disable color mode")
-	length       = flag.Int64("n", 0, "interpret only length bytes of input")
-	skip         = flag.Int64("s", 0, "skip first skip bytes of input")
-	width        = flag.Int("w", DefaultLineWidth, "output line width")
-	verbose      = flag.Bool("v", false, "show verbose messages")
-	debug        = flag.Bool("vv", false, "show debug messages")
+	precision  = flag.Int("precision", 0, "significant digits of floating-point numbers")
+	payload    = flag.Bool("nan-payload", false, "show payload of floating-point NaN values")
+	nibbles    = flag.Bool("nibbles", false, "separate nibbles of binary digits")
+	lsbFirst   = flag.Bool("lsb", false, "show binary digits with the least significant bit first")
+	color      = ColorAuto
+	noColor    = flag.Bool("no-color", false, "disable color mode")
+	classes    = flag.Bool("classes", false, "color bytes by their classes: NUL, printable, whitespace, control and high")
+	length     = flag.Int64("n", 0, "interpret only length bytes of input")
+	skip       = flag.Int64("s", 0, "skip first skip bytes of input")
+	width      = flag.Int("w", 0, "output line width (default 16 for table, 30 for plain hex and 12 for C include)")
+	plain      = flag.Bool("p", false, "output in continuous plain hex")
+	include    = flag.Bool("i", false, "output in C include file style")
+	varName    = flag.String("name", "", "variable name of source code (default derived from the input file)")
+	emit       = ModeTable
+	reverse    = flag.Bool("r", false, "reverse operation: convert a dump into binary")
+	verbose    = flag.Bool("v", false, "display all input data without squeezing identical lines")
+	debug      = flag.Bool("vv", false, "show debug messages")
+	radix      = RadixHex
+	offWidth   = flag.Int("offset-width", DefaultOffsetWidth, "minimum number of digits of offset")
+	offPrefix  = flag.String("offset-prefix", "", "prefix of offset, like 0x")
+	offUpper   = flag.Bool("offset-upper", false, "display hexadecimal offset in upper case")
+	group      = flag.Int("g", 0, "number of bytes in a group (default 8 cells)")
+	upper      = flag.Bool("u", false, "display hexadecimal digits of offset and cells in upper case")
+	cellSep    = flag.String("cell-sep", " ", "separator between cells")
+	groupSep   = flag.String("group-sep", "", "separator between groups (default the cell separator followed by a space)")
+	noSep      = flag.Bool("no-sep", false, "display cells without separators")
+	delims     = flag.String("delims", "|,|", "left and right delimiters of the char column separated by a comma, empty for none")
+	formats    []string
+	layout     *FormatLayout
+	styles     []DisplayStyle
+	theme      *ColorTheme
+	labels     = LabelNone
+	highlights []Option
 )
 
+// styleFlags are the flags of display styles, each style is displayed as a row of the lines in the order of flags.
+var styleFlags = []struct {
+	name, usage string
+	style       DisplayStyle
+}{
+	{"C", "canonical hex+ASCII display", StyleCanonical},
+	{"c", "one-byte char", StyleOneByteChar},
+	{"X", "one-byte hex", StyleOneByteHex},
+	{"b", "one-byte octal", StyleOneByteOctal},
+	{"B", "one-byte binary", StyleOneByteBinary},
+	{"d1", "one-byte decimal", StyleOneByteDec},
+	{"i1", "one-byte signed decimal", StyleOneByteSignedDec},
+	{"d", "two-byte decimal", StyleTwoBytesDec},
+	{"i2", "two-byte signed decimal", StyleTwoBytesSignedDec},
+	{"x", "two-byte hex", StyleTwoBytesHex},
+	{"o", "two-byte octal", StyleTwoBytesOctal},
+	{"d4", "four-byte decimal", StyleFourBytesDec},
+	{"i4", "four-byte signed decimal", StyleFourBytesSignedDec},
+	{"x4", "four-byte hex", StyleFourBytesHex},
+	{"o4", "four-byte octal", StyleFourBytesOctal},
+	{"d8", "eight-byte decimal", StyleEightBytesDec},
+	{"i8", "eight-byte signed decimal", StyleEightBytesSignedDec},
+	{"x8", "eight-byte hex", StyleEightBytesHex},
+	{"o8", "eight-byte octal", StyleEightBytesOctal},
+	{"f16", "half precision floating-point", StyleFloat16},
+	{"bf16", "brain floating-point", StyleBFloat16},
+	{"f32", "single precision floating-point", StyleFloat32},
+	{"f64", "double precision floating-point", StyleFloat64},
+}
+
 func main() {
+	if args, ok := odCommand(os.Args); ok {
+		if err := od(args); err != nil {
+			slog.Error("od", "err", err)
+			os.Exit(1)
+		}
+
+		return
+	}
+
+	if len(os.Args) > 1 {
+		if cmd, ok := subcommands[os.Args[1]]; ok {
+			if err := cmd(os.Args[2:]); err != nil {
+				slog.Error(os.Args[1], "err", err)
+				os.Exit(1)
+			}
+
+			return
+		}
+	}
+
 	flag.TextVar(&color, "L", color, "color mode")
+	flag.Bool("od", false, "dump the files like od(1), the other arguments are the options of od")
+	flag.TextVar(&emit, "emit", emit, "output mode (table, plain, include, go, rust, python, java, ihex, s19, s28, s37)")
+	flag.TextVar(&radix, "A", radix, "offset radix (hex, dec, octal, none)")
+	flag.Func("e", "format string of hexdump(1) applied to each block of input, may be repeated", addFormat)
+	flag.Func("f", "file of format strings, one per line", addFormatFile)
+	flag.Func("theme", "color theme by name ("+strings.Join(ThemeNames(), ", ")+") or theme file", setTheme)
+	flag.Func("highlight", "highlight bytes in start:end[:color[:label]], may be repeated", addHighlight)
+	flag.TextVar(&labels, "labels", labels, "labels of highlighted bytes (none, legend, inline)")
+	flag.Func("style", "display style by name ("+strings.Join(StyleNames(), ", ")+"), may be repeated", addStyleName)
+
+	for _, f := range styleFlags {
+		flag.BoolFunc(f.name, f.usage, func(string) error {
+			styles = append(styles, f.style)
+
+			return nil
+		})
+	}
+
 	flag.Parse()
 
 	initLogger()
 
+	if len(formats) > 0 {
+		var err error
+
+		if layout, err = ParseLayout(formats...); err != nil {
+			slog.Error("format layout", "err", err)
+			os.Exit(2)
+		}
+	}
+
+	styles := displayStyles()
+
+	process := dump
+	if *reverse {
+		process = undump
+	}
+
 	if flag.NArg() == 0 {
-		dump("-", os.Stdin)
+		process("-", os.Stdin, styles)
 	} else {
 		for _, name := range flag.Args() {
 			f, err := os.Open(name)
@@ -41,55 +147,209 @@ func main() {
 				slog.Warn("open file", "err", err)
 			}
 
-			dump(name, f)
+			process(name, f, styles)
 		}
 	}
 }
 
 func initLogger() {
-	switch {
-	case *debug:
+	if *debug {
 		slog.SetLogLoggerLevel(slog.LevelDebug)
-	case *verbose:
-		slog.SetLogLoggerLevel(slog.LevelInfo)
-	default:
+	} else {
 		slog.SetLogLoggerLevel(slog.LevelWarn)
 	}
 }
 
-func dump(name string, r io.Reader) {
+func dump(name string, r io.Reader, styles []DisplayStyle) {
 	opts := []Option{
-		Style(displayStyle()),
+		Style(styles[0]),
+		Styles(styles...),
 		Color(colorMode()),
 		Length(*length),
 		Skip(*skip),
 		LineWidth(*width),
+		Verbose(*verbose),
+		Mode(outputMode()),
+		Name(name),
+		Offset(offsetFormat()),
+		Cells(cellFormat()),
 	}
 
+	if *varName != "" {
+		opts = append(opts, Name(*varName))
+	}
+
+	if layout != nil {
+		opts = append(opts, Layout(layout))
+	}
+
+	if *classes {
+		opts = append(opts, Theme(&ByteClassTheme))
+	}
+
+	if theme != nil {
+		opts = append(opts, Theme(theme))
+	}
+
+	opts = append(opts, highlights...)
+	opts = append(opts, Labels(labels))
+
 	err := Stream(r, opts...)
 	if err != nil {
 		slog.Error("hexdump stream", "name", name, "err", err)
 	}
 }
 
-func displayStyle() DisplayStyle {
+func undump(name string, r io.Reader, styles []DisplayStyle) {
+	_, err := io.Copy(os.Stdout, Parse(r, Style(styles[0]), LineWidth(*width), Offset(offsetFormat()), Cells(cellFormat())))
+	if err != nil {
+		slog.Error("hexdump parse", "name", name, "err", err)
+	}
+}
+
+func addFormat(s string) error {
+	formats = append(formats, s)
+
+	return nil
+}
+
+func addFormatFile(name string) error {
+	f, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+
+	defer f.Close()
+
+	lines, err := ReadFormats(f)
+	if err != nil {
+		return err
+	}
+
+	formats = append(formats, lines...)
+
+	return nil
+}
+
+func setTheme(name string) (err error) {
+	if t, ok := LookupTheme(name); ok {
+		theme = t
+
+		return nil
+	}
+
+	f, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+
+	defer f.Close()
+
+	theme, err = ReadTheme(f)
+
+	return err
+}
+
+func addHighlight(s string) error {
+	fields := strings.SplitN(s, ":", 4)
+	if len(fields) < 2 {
+		return fmt.Errorf("highlight %q, %w", s, os.ErrInvalid)
+	}
+
+	start, err := strconv.ParseInt(fields[0], 0, 64)
+	if err != nil {
+		return err
+	}
+
+	end, err := strconv.ParseInt(fields[1], 0, 64)
+	if err != nil {
+		return err
+	}
+
+	spec, label := "reverse", ""
+
+	if len(fields) > 2 && fields[2] != "" {
+		spec = fields[2]
+	}
+
+	if len(fields) > 3 {
+		label = fields[3]
+	}
+
+	c, err := ParseColor(spec)
+	if err != nil {
+		return err
+	}
+
+	highlights = append(highlights, Highlight(start, end, c, label))
+
+	return nil
+}
+
+func addStyleName(name string) error {
+	s, ok := LookupStyle(name)
+	if !ok {
+		return fmt.Errorf("style %q, %w", name, os.ErrNotExist)
+	}
+
+	styles = append(styles, s)
+
+	return nil
+}
+
+// displayStyles returns the display styles of the rows with the floating-point and binary flags applied.
+func displayStyles() []DisplayStyle {
+	if len(styles) == 0 {
+		return []DisplayStyle{StyleCanonical}
+	}
+
+	result := make([]DisplayStyle, len(styles))
+
+	for i, s := range styles {
+		switch f := s.(type) {
+		case FloatStyle:
+			f.Precision = *precision
+			f.Payload = *payload
+			s = f
+
+		case BinaryStyle:
+			f.Nibbles = *nibbles
+			f.LSBFirst = *lsbFirst
+			s = f
+		}
+
+		result[i] = s
+	}
+
+	return result
+}
+
+func outputMode() OutputMode {
 	switch {
-	case *canonical:
-		return StyleCanonical
-	case *oneByteChar:
-		return StyleOneByteChar
-	case *oneByteHex:
-		return StyleOneByteHex
-	case *oneByteOctal:
-		return StyleOneByteOctal
-	case *twoBytesDec:
-		return StyleTwoBytesDec
-	case *twoBytesHex:
-		return StyleTwoBytesHex
-	case *twoBytesOct:
-		return StyleTwoBytesOctal
+	case *plain:
+		return ModePlain
+	case *include:
+		return ModeInclude
 	default:
-		return StyleCanonical
+		return emit
+	}
+}
+
+func offsetFormat() OffsetFormat {
+	return OffsetFormat{Radix: radix, Width: *offWidth, Upper: *offUpper || *upper, Prefix: *offPrefix}
+}
+
+func cellFormat() CellFormat {
+	left, right, _ := strings.Cut(*delims, ",")
+
+	return CellFormat{
+		Group:    *group,
+		CellSep:  *cellSep,
+		GroupSep: *groupSep,
+		NoSep:    *noSep,
+		Upper:    *upper,
+		Delims:   [2]string{left, right},
+		NoDelims: *delims == "",
 	}
 }
 
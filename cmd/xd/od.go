@@ -0,0 +1,533 @@
+package main
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	. "github.com/flier/hexdump" //nolint:revive,stylecheck
+)
+
+// odStyle is the display style of an output type of od(1), which is displayed as a row of fields under the offset.
+type odStyle struct {
+	*GroupStyle
+
+	trailer bool // Display the printable characters after the row.
+}
+
+// Field widths of od(1) indexed by the number of bytes.
+var (
+	odOctalDigits       = [...]int{0, 3, 6, 8, 11, 14, 16, 19, 22}
+	odSignedDecDigits   = [...]int{1, 4, 6, 8, 11, 13, 16, 18, 20}
+	odUnsignedDecDigits = [...]int{0, 3, 5, 8, 10, 13, 15, 17, 20}
+	odHexDigits         = [...]int{0, 2, 4, 6, 8, 10, 12, 14, 16}
+)
+
+const (
+	odFloatWidth  = 15
+	odDoubleWidth = 24
+)
+
+var odSizes = map[byte]int{'C': 1, 'S': 2, 'I': 4, 'L': 8, 'F': 4, 'D': 8}
+
+// parseODTypes parses the type specifications of `od -t`, like "x1z" or "d2u4", into the display styles of the rows.
+func parseODTypes(spec string) (styles []DisplayStyle, err error) {
+	for s := spec; s != ""; {
+		t := odStyle{GroupStyle: new(GroupStyle)}
+
+		kind := s[0]
+		s = s[1:]
+
+		switch kind {
+		case 'a', 'c':
+			t.Size, t.Width = 1, 3
+			t.Render = odChar(kind == 'a')
+
+		case 'd', 'o', 'u', 'x':
+			if t.Size, s, err = odSize(s, odSizes['I'], odSizes['C'], odSizes['S'], odSizes['I'], odSizes['L']); err != nil {
+				return nil, fmt.Errorf("type %q, %w", spec, err)
+			}
+
+			t.Width, t.Render = odInteger(kind, t.Size)
+
+		case 'f':
+			if t.Size, s, err = odSize(s, odSizes['D'], odSizes['F'], odSizes['D']); err != nil {
+				return nil, fmt.Errorf("type %q, %w", spec, err)
+			}
+
+			t.Width, t.Render = odFloat(t.Size)
+
+		default:
+			return nil, fmt.Errorf("type %q, %w", spec, os.ErrInvalid)
+		}
+
+		if s != "" && s[0] == 'z' {
+			t.trailer = true
+			s = s[1:]
+		}
+
+		styles = append(styles, t)
+	}
+
+	return
+}
+
+// odSize parses the size of a type specification, which is a number of bytes or one of C, S, I, L, F and D.
+func odSize(s string, size int, sizes ...int) (int, string, error) {
+	n := len(s) - len(strings.TrimLeft(s, "0123456789"))
+
+	switch {
+	case n > 0:
+		v, err := strconv.Atoi(s[:n])
+		if err != nil {
+			return 0, s, err
+		}
+
+		size, s = v, s[n:]
+
+	case s != "" && odSizes[s[0]] > 0:
+		size, s = odSizes[s[0]], s[1:]
+	}
+
+	if slices.Contains(sizes, size) {
+		return size, s, nil
+	}
+
+	return 0, s, fmt.Errorf("size %d, %w", size, os.ErrInvalid)
+}
+
+func odInteger(kind byte, size int) (int, func([]byte, binary.ByteOrder) string) {
+	switch kind {
+	case 'd':
+		return odSignedDecDigits[size], func(b []byte, order binary.ByteOrder) string {
+			return strconv.FormatInt(odInt(b, order), 10)
+		}
+
+	case 'o':
+		return odOctalDigits[size], func(b []byte, order binary.ByteOrder) string {
+			return fmt.Sprintf("%0*o", odOctalDigits[size], odUint(b, order))
+		}
+
+	case 'u':
+		return odUnsignedDecDigits[size], func(b []byte, order binary.ByteOrder) string {
+			return strconv.FormatUint(odUint(b, order), 10)
+		}
+
+	default:
+		return odHexDigits[size], func(b []byte, order binary.ByteOrder) string {
+			return fmt.Sprintf("%0*x", odHexDigits[size], odUint(b, order))
+		}
+	}
+}
+
+// odFloat renders the floating-point numbers with the fewest digits that round-trip the value, like od(1).
+func odFloat(size int) (int, func([]byte, binary.ByteOrder) string) {
+	single := size == odSizes['F']
+
+	bits, width, digits, smallest := 64, odDoubleWidth, 15, math.SmallestNonzeroFloat64*(1<<52)
+	if single {
+		bits, width, digits, smallest = 32, odFloatWidth, 6, math.SmallestNonzeroFloat32*(1<<23)
+	}
+
+	return width, func(b []byte, order binary.ByteOrder) string {
+		v := math.Float64frombits(odUint(b, order))
+		if single {
+			v = float64(math.Float32frombits(uint32(odUint(b, order)))) //nolint:gosec
+		}
+
+		switch {
+		case math.IsNaN(v):
+			if math.Signbit(v) {
+				return "-nan"
+			}
+
+			return "nan"
+
+		case math.IsInf(v, 1):
+			return "inf"
+
+		case math.IsInf(v, -1):
+			return "-inf"
+		}
+
+		prec := digits
+		if math.Abs(v) < smallest {
+			prec = 1
+		}
+
+		for ; ; prec++ {
+			s := strconv.FormatFloat(v, 'g', prec, bits)
+
+			if f, err := strconv.ParseFloat(s, bits); err != nil || f == v {
+				return s
+			}
+		}
+	}
+}
+
+// odUint reads the bytes of a field as an unsigned integer in the byte order.
+func odUint(b []byte, order binary.ByteOrder) uint64 {
+	switch len(b) {
+	case odSizes['C']:
+		return uint64(b[0])
+	case odSizes['S']:
+		return uint64(order.Uint16(b))
+	case odSizes['I']:
+		return uint64(order.Uint32(b))
+	default:
+		return order.Uint64(b)
+	}
+}
+
+// odInt reads the bytes of a field as a signed integer in the byte order.
+func odInt(b []byte, order binary.ByteOrder) int64 {
+	shift := 64 - 8*len(b)
+
+	return int64(odUint(b, order)<<shift) >> shift //nolint:gosec
+}
+
+var odNames = [...]string{
+	"nul", "soh", "stx", "etx", "eot", "enq", "ack", "bel", "bs", "ht", "nl", "vt", "ff", "cr", "so", "si",
+	"dle", "dc1", "dc2", "dc3", "dc4", "nak", "syn", "etb", "can", "em", "sub", "esc", "fs", "gs", "rs", "us", "sp",
+}
+
+// odChar renders a byte as a named character of `od -t a`, or a C escape of `od -t c`.
+func odChar(named bool) func([]byte, binary.ByteOrder) string {
+	return func(b []byte, _ binary.ByteOrder) string {
+		c := b[0]
+
+		if named {
+			switch c &= 0x7f; {
+			case c == 0x7f:
+				return "del"
+			case int(c) < len(odNames):
+				return odNames[c]
+			default:
+				return string(c)
+			}
+		}
+
+		if i := strings.IndexByte("\x00\a\b\f\n\r\t\v", c); i >= 0 {
+			return `\` + string(`0abfnrtv`[i])
+		}
+
+		if c < ' ' || c > '~' {
+			return fmt.Sprintf("%03o", c)
+		}
+
+		return string(c)
+	}
+}
+
+// odFormatter writes the blocks of binary content like od(1),
+// the rows of the display styles of the dumper are stacked under the offset.
+type odFormatter struct {
+	w      *bufio.Writer
+	styles []DisplayStyle
+	pads   []int // The total padding of the fields of each style to align the rows.
+	width  int   // The number of bytes per block.
+	order  binary.ByteOrder
+	offset OffsetFormat
+}
+
+func newODFormatter(d *Dumper) *odFormatter {
+	f := &odFormatter{
+		w:      bufio.NewWriter(d.Output),
+		styles: d.Styles,
+		width:  d.LineWidth,
+		order:  d.ByteOrder,
+		offset: d.Offset,
+	}
+
+	var blockWidth int
+
+	for _, s := range f.styles {
+		blockWidth = max(blockWidth, (s.CellWidth()+1)*(f.width/s.GroupSize()))
+	}
+
+	for _, s := range f.styles {
+		f.pads = append(f.pads, blockWidth-s.CellWidth()*(f.width/s.GroupSize()))
+	}
+
+	return f
+}
+
+func (f *odFormatter) FormatLine(off int64, _ int, buf []byte) error {
+	block := make([]byte, f.width)
+	copy(block, buf)
+
+	for i, s := range f.styles {
+		if i == 0 {
+			_, _ = f.w.WriteString(f.offset.Format(off))
+		} else {
+			_, _ = f.w.WriteString(strings.Repeat(" ", len(f.offset.Format(off))))
+		}
+
+		size, width := s.GroupSize(), s.CellWidth()
+		fields := f.width / size
+		blank := (f.width - len(buf)) / size
+		pad := f.pads[i]
+		rest := pad
+
+		for n := fields; n > blank; n-- {
+			next := pad * (n - 1) / fields
+			field := block[(fields-n)*size:][:size]
+
+			_, _ = fmt.Fprintf(f.w, "%*s", rest-next+width, s.FormatGroup(field, f.order))
+
+			rest = next
+		}
+
+		if t, ok := s.(odStyle); ok && t.trailer {
+			_, _ = f.w.WriteString(strings.Repeat(" ", blank*width+pad*blank/fields) + "  >")
+
+			for _, c := range buf {
+				if c < ' ' || c > '~' {
+					c = '.'
+				}
+
+				_ = f.w.WriteByte(c)
+			}
+
+			_ = f.w.WriteByte('<')
+		}
+
+		_ = f.w.WriteByte('\n')
+	}
+
+	return f.w.Flush()
+}
+
+func (f *odFormatter) FormatSqueeze() error {
+	_, err := f.w.WriteString("*\n")
+
+	return errors.Join(err, f.w.Flush())
+}
+
+func (f *odFormatter) FormatEnd(off int64) error {
+	if offset := f.offset.Format(off); offset != "" {
+		_, _ = f.w.WriteString(offset + "\n")
+	}
+
+	return f.w.Flush()
+}
+
+// odTraditional are the traditional flags of od(1) and their equivalent types.
+var odTraditional = []struct {
+	name, usage, spec string
+}{
+	{"b", "octal bytes, same as -t o1", "o1"},
+	{"c", "printable chars or backslash escapes, same as -t c", "c"},
+	{"d", "unsigned decimal 2-byte units, same as -t u2", "u2"},
+	{"o", "octal 2-byte units, same as -t o2", "o2"},
+	{"s", "decimal 2-byte units, same as -t d2", "d2"},
+	{"x", "hexadecimal 2-byte units, same as -t x2", "x2"},
+}
+
+// Widths of the offsets of od(1) by radix.
+const (
+	odOffsetWidth    = 7
+	odHexOffsetWidth = 6
+)
+
+// odCommand reports whether xd behaves like od(1), when it is invoked as od or with the --od flag before the files,
+// and returns the arguments of od without the flag.
+func odCommand(args []string) ([]string, bool) {
+	if filepath.Base(args[0]) == "od" {
+		return args[1:], true
+	}
+
+	for i, arg := range args[1:] {
+		switch arg {
+		case "--":
+			return nil, false
+		case "-od", "--od":
+			return slices.Delete(slices.Clone(args[1:]), i, i+1), true
+		}
+	}
+
+	return nil, false
+}
+
+// od dumps the files like od(1).
+func od(args []string) error {
+	opts, names, err := parseOD(args)
+	if errors.Is(err, flag.ErrHelp) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	readers := []io.Reader{os.Stdin}
+
+	if len(names) > 0 {
+		readers = nil
+
+		for _, name := range names {
+			f, err := os.Open(name)
+			if err != nil {
+				return err
+			}
+
+			defer f.Close()
+
+			readers = append(readers, f)
+		}
+	}
+
+	return odDump(io.MultiReader(readers...), os.Stdout, opts...)
+}
+
+// odDump dumps the input to the writer like od(1) with the options of [parseOD].
+func odDump(r io.Reader, w io.Writer, x ...Option) error {
+	x = append(x, Output(w))
+
+	return Stream(r, append(x, LineFormat(newODFormatter(New(x...))))...)
+}
+
+// parseOD parses the arguments of od(1), and returns the options of the dumper and the names of the input files.
+func parseOD(args []string) ([]Option, []string, error) {
+	fs := flag.NewFlagSet("od", flag.ContinueOnError)
+
+	var styles []DisplayStyle
+
+	radix := fs.String("A", "o", "output format for file offsets (d, o, x or n)")
+	skip := fs.String("j", "0", "skip bytes of input first")
+	length := fs.String("N", "0", "limit dump to bytes of input")
+	verbose := fs.Bool("v", false, "do not use * to mark line suppression")
+	width := fs.Int("w", DefaultLineWidth, "output bytes per output line, -w without a number implies 32")
+	endian := fs.String("endian", "", "swap input bytes according the specified order (big or little)")
+
+	fs.Func("t", "select output format or formats, like x1z, may be repeated", func(s string) error {
+		t, err := parseODTypes(s)
+		styles = append(styles, t...)
+
+		return err
+	})
+
+	for _, t := range odTraditional {
+		fs.BoolFunc(t.name, t.usage, func(string) error {
+			t, err := parseODTypes(t.spec)
+			styles = append(styles, t...)
+
+			return err
+		})
+	}
+
+	if err := fs.Parse(odArgs(args)); err != nil {
+		return nil, nil, err
+	}
+
+	if len(styles) == 0 {
+		styles, _ = parseODTypes("o2")
+	}
+
+	offset := OffsetFormat{Width: odOffsetWidth}
+
+	if len(*radix) != 1 || offset.Radix.UnmarshalText([]byte(*radix)) != nil {
+		return nil, nil, fmt.Errorf("address radix %q, %w", *radix, os.ErrInvalid)
+	}
+
+	if offset.Radix == RadixHex {
+		offset.Width = odHexOffsetWidth
+	}
+
+	for _, s := range styles {
+		if *width <= 0 || *width%s.GroupSize() != 0 {
+			return nil, nil, fmt.Errorf("width %d, %w", *width, os.ErrInvalid)
+		}
+	}
+
+	var order binary.ByteOrder = binary.NativeEndian
+
+	switch *endian {
+	case "":
+	case "big":
+		order = binary.BigEndian
+	case "little":
+		order = binary.LittleEndian
+	default:
+		return nil, nil, fmt.Errorf("endian %q, %w", *endian, os.ErrInvalid)
+	}
+
+	n, err := parseODSize(*skip)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	limit, err := parseODSize(*length)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return []Option{
+		Styles(styles...), LineWidth(*width), ByteOrder(order), Offset(offset),
+		Skip(n), Length(limit), Verbose(*verbose),
+	}, fs.Args(), nil
+}
+
+const odDefaultWidth = 32
+
+// odArgs rewrites the arguments of od(1) which the flag package can't parse:
+// -wN sets the width to N and -w alone implies 32, the values of -A, -j, -N and -t may be attached like -Ax.
+func odArgs(args []string) []string {
+	args = slices.Clone(args)
+
+	for i, arg := range args {
+		if arg == "--" {
+			break
+		}
+
+		if n, ok := strings.CutPrefix(arg, "-w"); ok && !strings.HasPrefix(n, "=") {
+			if n == "" {
+				n = strconv.Itoa(odDefaultWidth)
+			}
+
+			args[i] = "-w=" + n
+		} else if len(arg) > 2 && arg[0] == '-' && strings.IndexByte("AjNt", arg[1]) >= 0 && arg[2] != '=' {
+			args[i] = arg[:2] + "=" + arg[2:]
+		}
+	}
+
+	return args
+}
+
+var odMultipliers = []struct {
+	suffix string
+	n      int64
+}{
+	{"b", 512}, {"kB", 1000}, {"K", 1 << 10}, {"MB", 1000 * 1000}, {"M", 1 << 20}, {"GB", 1000 * 1000 * 1000}, {"G", 1 << 30},
+}
+
+// parseODSize parses a number of bytes with an optional multiplier suffix, like 0x10 or 4K.
+func parseODSize(s string) (int64, error) {
+	m := int64(1)
+
+	for _, x := range odMultipliers {
+		if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+			break
+		}
+
+		if v, ok := strings.CutSuffix(s, x.suffix); ok {
+			s, m = v, x.n
+
+			break
+		}
+	}
+
+	n, err := strconv.ParseInt(s, 0, 64)
+	if err != nil {
+		return 0, fmt.Errorf("size %q, %w", s, err)
+	}
+
+	return n * m, nil
+}
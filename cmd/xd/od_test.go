@@ -0,0 +1,249 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestParseODTypes(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given the type specifications of od", t, func() {
+		for _, tc := range []struct {
+			spec    string
+			sizes   []int
+			widths  []int
+			trailer []bool
+		}{
+			{"x1z", []int{1}, []int{2}, []bool{true}},
+			{"d2u4", []int{2, 4}, []int{6, 10}, []bool{false, false}},
+			{"oCxSdIuL", []int{1, 2, 4, 8}, []int{3, 4, 11, 20}, []bool{false, false, false, false}},
+			{"x", []int{4}, []int{8}, []bool{false}},
+			{"fFfDf", []int{4, 8, 8}, []int{15, 24, 24}, []bool{false, false, false}},
+			{"acz", []int{1, 1}, []int{3, 3}, []bool{false, true}},
+		} {
+			Convey("When parse "+tc.spec, func() {
+				styles, err := parseODTypes(tc.spec)
+
+				Convey("Then the styles should have the sizes and widths of od", func() {
+					So(err, ShouldBeNil)
+					So(styles, ShouldHaveLength, len(tc.sizes))
+
+					for i, s := range styles {
+						So(s.GroupSize(), ShouldEqual, tc.sizes[i])
+						So(s.CellWidth(), ShouldEqual, tc.widths[i])
+						So(s.(odStyle).trailer, ShouldEqual, tc.trailer[i])
+					}
+				})
+			})
+		}
+
+		for _, spec := range []string{"q", "x3", "f2", "dZ", "u16"} {
+			Convey("When parse the invalid "+spec, func() {
+				_, err := parseODTypes(spec)
+
+				Convey("Then it should fail", func() {
+					So(err, ShouldWrap, os.ErrInvalid)
+				})
+			})
+		}
+	})
+}
+
+func TestParseODSize(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given the sizes of od", t, func() {
+		for _, tc := range []struct {
+			s string
+			n int64
+		}{
+			{"0", 0}, {"12", 12}, {"010", 8}, {"0x10", 16}, {"0X1b", 27},
+			{"1b", 512}, {"2kB", 2000}, {"4K", 4096}, {"1MB", 1000000}, {"1M", 1 << 20}, {"1GB", 1000000000}, {"1G", 1 << 30},
+		} {
+			Convey("When parse "+tc.s, func() {
+				n, err := parseODSize(tc.s)
+
+				Convey("Then the number of bytes should be returned", func() {
+					So(err, ShouldBeNil)
+					So(n, ShouldEqual, tc.n)
+				})
+			})
+		}
+
+		Convey("When parse an invalid size", func() {
+			_, err := parseODSize("4X")
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
+
+func TestODArgs(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given the arguments of od", t, func() {
+		for _, tc := range []struct {
+			args, want string
+		}{
+			{"-w", "-w=32"},
+			{"-w8 -v", "-w=8 -v"},
+			{"-w=8", "-w=8"},
+			{"-w 8", "-w=32 8"},
+			{"-Ax -tx1z -j1 -N13", "-A=x -t=x1z -j=1 -N=13"},
+			{"-A x -t x1", "-A x -t x1"},
+			{"-t=x1 -- -w -Ax", "-t=x1 -- -w -Ax"},
+		} {
+			Convey("When rewrite "+tc.args, func() {
+				args := odArgs(strings.Fields(tc.args))
+
+				Convey("Then the arguments should be parsed by the flag package", func() {
+					So(strings.Join(args, " "), ShouldEqual, tc.want)
+				})
+			})
+		}
+	})
+}
+
+func TestOD(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given some binary content", t, func() {
+		in := []byte("hello, world\n\x00\xff\x80abc")
+
+		// The outputs of GNU od 9.1 with --endian=little.
+		for _, tc := range []struct {
+			args, want string
+		}{
+			{"-A x -t x1z -v", `
+000000 68 65 6c 6c 6f 2c 20 77 6f 72 6c 64 0a 00 ff 80  >hello, world....<
+000010 61 62 63                                         >abc<
+000013
+`},
+			{"-t x1 -t c -t a", `
+0000000  68  65  6c  6c  6f  2c  20  77  6f  72  6c  64  0a  00  ff  80
+          h   e   l   l   o   ,       w   o   r   l   d  \n  \0 377 200
+          h   e   l   l   o   ,  sp   w   o   r   l   d  nl nul del nul
+0000020  61  62  63
+          a   b   c
+          a   b   c
+0000023
+`},
+			{"-A d -t d2 -t f4 -t u8 -w8 -j1 -N13", `
+0000001   27749   28524    8236   28535
+          7.3169503e+28    7.648169e+28
+                    8031923835658595429
+0000009   27762    2660       0
+          1.0998197e-32               0
+                              174353522
+0000014
+`},
+			{"-t x2 -t o1 -N3", `
+0000000    6568    006c
+        150 145 154
+0000003
+`},
+			{"-A n -b -w4 -N6", `
+ 150 145 154 154
+ 157 054
+`},
+			{"-Ax -tx1 -j4 -N5", `
+000004 6f 2c 20 77 6f
+000009
+`},
+		} {
+			Convey("When dump with "+tc.args, func() {
+				var b bytes.Buffer
+
+				opts, names, err := parseOD(append(strings.Fields(tc.args), "-endian", "little"))
+				So(err, ShouldBeNil)
+				So(names, ShouldBeEmpty)
+
+				err = odDump(bytes.NewReader(in), &b, opts...)
+
+				Convey("Then the output should be the same as od", func() {
+					So(err, ShouldBeNil)
+					So(b.String(), ShouldEqual, strings.TrimPrefix(tc.want, "\n"))
+				})
+			})
+		}
+	})
+
+	Convey("Given identical lines", t, func() {
+		in := make([]byte, 48)
+
+		for _, tc := range []struct {
+			args, want string
+		}{
+			{"-A x -t x1", `
+000000 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00
+*
+000030
+`},
+			{"-A x -t x1 -v -w32", `
+000000 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00
+000020 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00
+000030
+`},
+		} {
+			Convey("When dump with "+tc.args, func() {
+				var b bytes.Buffer
+
+				opts, _, err := parseOD(strings.Fields(tc.args))
+				So(err, ShouldBeNil)
+
+				err = odDump(bytes.NewReader(in), &b, opts...)
+
+				Convey("Then the output should be the same as od", func() {
+					So(err, ShouldBeNil)
+					So(b.String(), ShouldEqual, strings.TrimPrefix(tc.want, "\n"))
+				})
+			})
+		}
+	})
+
+	Convey("Given the invalid arguments of od", t, func() {
+		for _, args := range []string{"-A q", "-A xx", "-t x8 -w12", "-w0", "-endian middle", "-t q", "-j 4X"} {
+			Convey("When parse "+args, func() {
+				_, _, err := parseOD(strings.Fields(args))
+
+				Convey("Then it should fail", func() {
+					So(err, ShouldNotBeNil)
+				})
+			})
+		}
+	})
+}
+
+func TestODCommand(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given the command lines of xd", t, func() {
+		for _, tc := range []struct {
+			args, want string
+			ok         bool
+		}{
+			{"/usr/bin/od -A x", "-A x", true},
+			{"xd --od -A x file", "-A x file", true},
+			{"xd -v --od file", "-v file", true},
+			{"xd -v -od", "-v", true},
+			{"xd -v file", "", false},
+			{"xd -- --od", "", false},
+		} {
+			Convey("When run "+tc.args, func() {
+				args, ok := odCommand(strings.Fields(tc.args))
+
+				Convey("Then the arguments of od should be returned", func() {
+					So(ok, ShouldEqual, tc.ok)
+					So(strings.Join(args, " "), ShouldEqual, tc.want)
+				})
+			})
+		}
+	})
+}
@@ -70,10 +70,76 @@ type ColorTheme struct {
 	Offset  *color.Color
 	Content *color.Color
 	Chars   *color.Color
+
+	// The colors of the byte classes applied to both the cell and the char of the bytes instead of Content and Chars,
+	// the bytes of a class without color, or a cell of the bytes in different classes, use the color of the column.
+	Null       *color.Color // The NUL byte.
+	Printable  *color.Color // The printable ASCII characters except space.
+	Whitespace *color.Color // The ASCII whitespace characters, including space.
+	Control    *color.Color // The other ASCII control characters.
+	High       *color.Color // The bytes greater than or equal to 0x80.
+
+	// The colors of the bytes which differ in the old and new inputs of a diff.
+	Old *color.Color
+	New *color.Color
 }
 
 var DefaultTheme = ColorTheme{
 	Offset:  color.New(color.Faint),
 	Content: color.New(color.Reset),
 	Chars:   color.New(color.Italic),
+	Old:     color.New(color.FgRed),
+	New:     color.New(color.FgGreen),
+}
+
+// ByteClassTheme is the theme which colors the bytes by their classes like hexyl.
+var ByteClassTheme = ColorTheme{
+	Offset:     color.New(color.Faint),
+	Content:    color.New(color.Reset),
+	Chars:      color.New(color.Reset),
+	Null:       color.New(color.FgHiBlack),
+	Printable:  color.New(color.FgCyan),
+	Whitespace: color.New(color.FgGreen),
+	Control:    color.New(color.FgMagenta),
+	High:       color.New(color.FgYellow),
+	Old:        color.New(color.FgRed, color.Bold),
+	New:        color.New(color.FgGreen, color.Bold),
+}
+
+// hasClasses returns true if any byte class has color.
+func (t *ColorTheme) hasClasses() bool {
+	return t.Null != nil || t.Printable != nil || t.Whitespace != nil || t.Control != nil || t.High != nil
+}
+
+// classColor returns the color of the class of the bytes, or nil if the bytes are in different classes.
+func (t *ColorTheme) classColor(b []byte) *color.Color {
+	if len(b) == 0 {
+		return nil
+	}
+
+	c := t.byteColor(b[0])
+
+	for _, x := range b[1:] {
+		if t.byteColor(x) != c {
+			return nil
+		}
+	}
+
+	return c
+}
+
+// byteColor returns the color of the class of a byte.
+func (t *ColorTheme) byteColor(c byte) *color.Color {
+	switch {
+	case c == 0:
+		return t.Null
+	case c >= 0x80:
+		return t.High
+	case c == ' ' || c >= '\t' && c <= '\r':
+		return t.Whitespace
+	case c < ' ' || c == 0x7f:
+		return t.Control
+	default:
+		return t.Printable
+	}
 }
@@ -0,0 +1,423 @@
+package hexdump
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/fatih/color"
+)
+
+// DefaultDiffWidth is the number of bytes per line of each input of a diff.
+const DefaultDiffWidth = 8
+
+// Change is a range of bytes which differ between the old and new inputs of a diff,
+// the range of an input without the bytes is empty at the offset they are missing.
+type Change struct {
+	OldStart, OldEnd int64 // The offsets [OldStart, OldEnd) of the bytes in the old input.
+	NewStart, NewEnd int64 // The offsets [NewStart, NewEnd) of the bytes in the new input.
+}
+
+// Kind returns "deleted" if the bytes are absent in the new input, "inserted" if they are absent in the old input,
+// otherwise "changed".
+func (c Change) Kind() string {
+	switch {
+	case c.NewStart == c.NewEnd:
+		return "deleted"
+	case c.OldStart == c.OldEnd:
+		return "inserted"
+	default:
+		return "changed"
+	}
+}
+
+// Diff reads the old input 'a' and the new input 'b', and writes their tables side by side,
+// the lines are paired by the edit script of the inputs and marked in the gutter between them with
+//
+//   - ' ' if the lines are identical;
+//   - '|' if the lines differ;
+//   - '<' if the line is only in the old input;
+//   - '>' if the line is only in the new input.
+//
+// The bytes at the same offsets are compared unless [Dumper.Align] is set,
+// otherwise the inserted and deleted runs of bytes are displayed as gaps in the other input,
+// and the inputs are resynchronized at the identical bytes after them.
+//
+// The differing bytes are highlighted with the Old and New colors of the theme,
+// the runs of identical lines are replaced with a line containing a single '*' unless [Dumper.Verbose] is set,
+// keeping the first and last lines of the runs around the changes.
+// A summary of the changed ranges follows the tables.
+//
+// The line width is [DefaultDiffWidth] if not set, the [Skip] and [Length] options apply to both inputs.
+//
+// The function returns the changed ranges, which are empty if the inputs are identical.
+func Diff(a, b io.Reader, x ...Option) (changes []Change, err error) {
+	d := New(x...)
+
+	if d.LineWidth == 0 {
+		d.LineWidth = DefaultDiffWidth
+	}
+
+	d.setDefaults()
+
+	initColor(d.Output, d.Color)
+
+	end := d.Start + max(inputSize(a), inputSize(b))
+
+	if d.Skip > 0 {
+		for _, r := range []io.Reader{a, b} {
+			if _, err = io.CopyN(io.Discard, r, d.Skip); err != nil && !errors.Is(err, io.EOF) {
+				return nil, err
+			}
+		}
+	}
+
+	if d.Length > 0 {
+		end = min(end, d.Start+d.Skip+d.Length)
+		a, b = io.LimitReader(a, d.Length), io.LimitReader(b, d.Length)
+	}
+
+	df := newDiffer(d, end)
+
+	if err = editScript(a, b, d.Align, df.add); err != nil {
+		return nil, err
+	}
+
+	if err = df.close(); err != nil {
+		return nil, err
+	}
+
+	return df.changes, nil
+}
+
+// diffSide is the bytes of an input in a line of the diff.
+type diffSide struct {
+	off        int64            // The offset of the first byte.
+	skip       int              // The column of the first byte.
+	b          []byte           // The bytes of the input.
+	highlights []HighlightRange // The ranges of the differing bytes.
+	closed     bool             // A gap follows the bytes, so no more bytes can be added.
+}
+
+// add adds the bytes at the offset to the column of the line, the differing bytes are highlighted with the color.
+func (s *diffSide) add(off int64, col int, b []byte, changed bool, c *color.Color) {
+	if len(s.b) == 0 {
+		s.off, s.skip = off, col
+	}
+
+	s.b = append(s.b, b...)
+
+	if !changed {
+		return
+	}
+
+	if n := len(s.highlights); n > 0 && s.highlights[n-1].End == off {
+		s.highlights[n-1].End += int64(len(b))
+	} else {
+		s.highlights = append(s.highlights, HighlightRange{off, off + int64(len(b)), c, ""})
+	}
+}
+
+// accepts reports whether a byte at the offset can be added to the column of the line,
+// the bytes must be contiguous, and the offset of the line must be the offset of the first byte
+// or aligned to the line width.
+func (s *diffSide) accepts(off int64, col, width int) bool {
+	if len(s.b) > 0 {
+		return !s.closed
+	}
+
+	return col == 0 || off%int64(width) == int64(col)
+}
+
+// diffLine is a line of the old and new inputs, either of which may be empty.
+type diffLine struct {
+	col      int // The next column of the line.
+	old, new diffSide
+	equal    bool
+}
+
+// marker returns the mark of the line in the gutter.
+func (l *diffLine) marker() byte {
+	switch {
+	case l.equal:
+		return ' '
+	case len(l.old.b) == 0:
+		return '>'
+	case len(l.new.b) == 0:
+		return '<'
+	default:
+		return '|'
+	}
+}
+
+// differ writes the lines of the old and new inputs side by side.
+type differ struct {
+	*bufio.Writer
+	*ColorTheme
+	offset         OffsetFormat
+	width          int
+	verbose        bool
+	old, new       *Formatter
+	buf            bytes.Buffer // The rendered line of a side.
+	changes        []Change
+	open           bool      // The last change continues to the next hunk.
+	oldOff, newOff int64     // The offsets of the next bytes of the inputs.
+	line           *diffLine // The line being filled.
+	pending        *diffLine // The last identical line not yet written.
+	squeezed       bool      // The identical lines before the pending line were squeezed.
+	context        bool      // The next identical line is written as the context after a change.
+}
+
+func newDiffer(d *Dumper, end int64) *differ {
+	df := &differ{
+		Writer:     bufio.NewWriter(d.Output),
+		ColorTheme: d.Theme,
+		offset:     d.Offset.fit(end),
+		width:      d.LineWidth,
+		verbose:    d.Verbose,
+		oldOff:     d.Start + d.Skip,
+		newOff:     d.Start + d.Skip,
+		context:    true,
+	}
+
+	side := func() *Formatter {
+		return &Formatter{
+			Writer:       bufio.NewWriter(&df.buf),
+			ColorTheme:   d.Theme,
+			DisplayStyle: StyleCanonical,
+			ByteOrder:    d.ByteOrder,
+			LineWidth:    d.LineWidth,
+			OffsetFormat: df.offset,
+			Cells:        d.Cells,
+		}
+	}
+
+	df.old, df.new = side(), side()
+
+	return df
+}
+
+// add adds a hunk of the edit script to the changes and lines,
+// the replaced bytes are paired and the rest of the longer side is displayed with a gap in the other side.
+func (df *differ) add(h hunk) error {
+	if h.equal {
+		df.open = false
+
+		return df.addRun(h.old, h.new, false)
+	}
+
+	if df.open {
+		c := &df.changes[len(df.changes)-1]
+		c.OldEnd += int64(len(h.old))
+		c.NewEnd += int64(len(h.new))
+	} else {
+		df.changes = append(df.changes, Change{
+			df.oldOff, df.oldOff + int64(len(h.old)),
+			df.newOff, df.newOff + int64(len(h.new)),
+		})
+		df.open = true
+	}
+
+	n := min(len(h.old), len(h.new))
+
+	return errors.Join(
+		df.addRun(h.old[:n], h.new[:n], true),
+		df.addRun(h.old[n:], nil, true),
+		df.addRun(nil, h.new[n:], true))
+}
+
+// addRun adds the bytes of the inputs to the lines, the old and new bytes are paired if both present.
+func (df *differ) addRun(old, new []byte, changed bool) error {
+	for len(old) > 0 || len(new) > 0 {
+		l, err := df.nextLine(len(old) > 0, len(new) > 0)
+		if err != nil {
+			return err
+		}
+
+		n := df.width - l.col
+
+		if len(old) > 0 {
+			n = min(n, len(old))
+			l.old.add(df.oldOff, l.col, old[:n], changed, df.Old)
+			old = old[n:]
+			df.oldOff += int64(n)
+		} else if len(l.old.b) > 0 {
+			l.old.closed = true
+		}
+
+		if len(new) > 0 {
+			n = min(n, len(new))
+			l.new.add(df.newOff, l.col, new[:n], changed, df.New)
+			new = new[n:]
+			df.newOff += int64(n)
+		} else if len(l.new.b) > 0 {
+			l.new.closed = true
+		}
+
+		l.col += n
+		l.equal = l.equal && !changed
+	}
+
+	return nil
+}
+
+// nextLine returns the line which the next bytes of the inputs can be added to,
+// the current line is finished if it is full or can't accept the bytes.
+func (df *differ) nextLine(hasOld, hasNew bool) (*diffLine, error) {
+	if l := df.line; l != nil {
+		if l.col < df.width && (!hasOld || l.old.accepts(df.oldOff, l.col, df.width)) &&
+			(!hasNew || l.new.accepts(df.newOff, l.col, df.width)) {
+			return l, nil
+		}
+
+		if err := df.finish(l); err != nil {
+			return nil, err
+		}
+	}
+
+	// The line starts at the column of the offsets aligned to the line width, or 0 if they are not aligned.
+	col := -1
+
+	for _, side := range []struct {
+		present bool
+		off     int64
+	}{{hasOld, df.oldOff}, {hasNew, df.newOff}} {
+		if !side.present {
+			continue
+		}
+
+		if c := int(side.off % int64(df.width)); col < 0 || c == col {
+			col = c
+		} else {
+			col = 0
+		}
+	}
+
+	df.line = &diffLine{col: max(col, 0), equal: true}
+
+	return df.line, nil
+}
+
+// finish writes a filled line unless it is identical and squeezed.
+func (df *differ) finish(l *diffLine) error {
+	if l.equal {
+		switch {
+		case df.verbose || df.context:
+			df.context = false
+
+			return df.writeLine(l)
+
+		case df.pending != nil && !df.squeezed:
+			df.squeezed = true
+
+			if _, err := df.WriteString("*\n"); err != nil {
+				return err
+			}
+		}
+
+		df.pending = l
+
+		return nil
+	}
+
+	if df.pending != nil {
+		if err := df.writeLine(df.pending); err != nil {
+			return err
+		}
+	}
+
+	df.pending, df.squeezed, df.context = nil, false, true
+
+	return df.writeLine(l)
+}
+
+// close writes the last lines and the summary of the changes.
+func (df *differ) close() (err error) {
+	if df.line != nil {
+		if err = df.finish(df.line); err != nil {
+			return
+		}
+	}
+
+	if df.pending != nil {
+		if err = df.writeLine(df.pending); err != nil {
+			return
+		}
+	}
+
+	return errors.Join(df.writeSummary(), df.Flush())
+}
+
+// writeLine writes the sides of a line separated by the gutter.
+func (df *differ) writeLine(l *diffLine) (err error) {
+	left, err := df.render(df.old, &l.old)
+	if err != nil {
+		return
+	}
+
+	right, err := df.render(df.new, &l.new)
+	if err != nil {
+		return
+	}
+
+	if left == "" {
+		left = spaces(utf8.RuneCountInString(ansiEscape.ReplaceAllString(right, "")))
+	}
+
+	line := left + "  " + string(l.marker())
+	if right != "" {
+		line += "  " + right
+	}
+
+	_, err = df.WriteString(line + "\n")
+
+	return
+}
+
+// render returns the line of a side without the newline, or an empty string if the side has no bytes.
+func (df *differ) render(f *Formatter, s *diffSide) (string, error) {
+	if len(s.b) == 0 {
+		return "", nil
+	}
+
+	df.buf.Reset()
+
+	f.Highlights = s.highlights
+
+	if err := f.FormatLine(s.off-int64(s.skip), s.skip, s.b); err != nil {
+		return "", err
+	}
+
+	return strings.TrimSuffix(df.buf.String(), "\n"), nil
+}
+
+// writeSummary writes a line of the ranges of the old and new inputs and the kind of each change.
+func (df *differ) writeSummary() (err error) {
+	offset := df.offset
+	if offset.Radix == RadixNone {
+		offset.Radix = RadixHex
+	}
+
+	for _, c := range df.changes {
+		kind := c.Kind()
+
+		switch {
+		case kind == "deleted" && df.Old != nil:
+			kind = df.Old.Sprint(kind)
+		case kind == "inserted" && df.New != nil:
+			kind = df.New.Sprint(kind)
+		}
+
+		text := df.Offset.Sprint(offset.Format(c.OldStart)+"-"+offset.Format(c.OldEnd)) + "  " +
+			df.Offset.Sprint(offset.Format(c.NewStart)+"-"+offset.Format(c.NewEnd)) + "  " + kind + "\n"
+
+		if _, err = df.WriteString(text); err != nil {
+			return
+		}
+	}
+
+	return
+}
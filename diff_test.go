@@ -0,0 +1,152 @@
+package hexdump_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/flier/hexdump"
+)
+
+func ExampleDiff() {
+	changes, _ := hexdump.Diff(
+		strings.NewReader("hello world, this is a test"),
+		strings.NewReader("hello World, this is a test!"))
+
+	fmt.Println(len(changes), "changes")
+	// Output:
+	// 00000000  68 65 6c 6c 6f 20 77 6f  |hello wo|  |  00000000  68 65 6c 6c 6f 20 57 6f  |hello Wo|
+	// 00000008  72 6c 64 2c 20 74 68 69  |rld, thi|     00000008  72 6c 64 2c 20 74 68 69  |rld, thi|
+	// 00000010  73 20 69 73 20 61 20 74  |s is a t|     00000010  73 20 69 73 20 61 20 74  |s is a t|
+	// 00000018  65 73 74                 |est     |  |  00000018  65 73 74 21              |est!    |
+	// 00000006-00000007  00000006-00000007  changed
+	// 0000001b-0000001b  0000001b-0000001c  inserted
+	// 2 changes
+}
+
+func ExampleDiff_deleted() {
+	_, _ = hexdump.Diff(
+		strings.NewReader("0123456789abcdefXYZ"),
+		strings.NewReader("0123456789abcdef"),
+		hexdump.Verbose(true))
+	// Output:
+	// 00000000  30 31 32 33 34 35 36 37  |01234567|     00000000  30 31 32 33 34 35 36 37  |01234567|
+	// 00000008  38 39 61 62 63 64 65 66  |89abcdef|     00000008  38 39 61 62 63 64 65 66  |89abcdef|
+	// 00000010  58 59 5a                 |XYZ     |  <
+	// 00000010-00000013  00000010-00000010  deleted
+}
+
+func ExampleAlign() {
+	_, _ = hexdump.Diff(
+		strings.NewReader("hello world, this is a test of the aligned diff"),
+		strings.NewReader("hello, world, this is a test of aligned diff!"),
+		hexdump.Align(true))
+	// Output:
+	// 00000000  68 65 6c 6c 6f           |hello   |  |  00000000  68 65 6c 6c 6f 2c        |hello,  |
+	// 00000005  20 77 6f 72 6c 64 2c 20  | world, |     00000006  20 77 6f 72 6c 64 2c 20  | world, |
+	// *
+	// 00000015  61 20 74 65 73 74 20 6f  |a test o|     00000016  61 20 74 65 73 74 20 6f  |a test o|
+	// 0000001d  66 20 74 68 65 20        |f the   |  |  0000001e  66 20                    |f       |
+	// 00000023  61 6c 69 67 6e 65 64 20  |aligned |     00000020  61 6c 69 67 6e 65 64 20  |aligned |
+	// 0000002b  64 69 66 66              |diff    |  |  00000028  64 69 66 66 21           |diff!   |
+	// 00000005-00000005  00000005-00000006  inserted
+	// 0000001f-00000023  00000020-00000020  deleted
+	// 0000002f-0000002f  0000002c-0000002d  inserted
+}
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given two inputs", t, func() {
+		a := strings.Repeat("\x00", 64) + "old"
+		b := strings.Repeat("\x00", 64) + "new"
+
+		Convey("When diff the identical inputs", func() {
+			var w strings.Builder
+
+			changes, err := hexdump.Diff(strings.NewReader(a), strings.NewReader(a), hexdump.Output(&w))
+
+			Convey("Then there should be no change and the identical lines should be squeezed", func() {
+				So(err, ShouldBeNil)
+				So(changes, ShouldBeEmpty)
+				So(strings.Split(w.String(), "\n"), ShouldHaveLength, 4)
+				So(w.String(), ShouldContainSubstring, "\n*\n")
+			})
+		})
+
+		Convey("When diff the inputs", func() {
+			var w strings.Builder
+
+			changes, err := hexdump.Diff(strings.NewReader(a), strings.NewReader(b), hexdump.Output(&w))
+
+			Convey("Then the changed ranges should be returned", func() {
+				So(err, ShouldBeNil)
+				So(changes, ShouldResemble, []hexdump.Change{{64, 67, 64, 67}})
+				So(changes[0].Kind(), ShouldEqual, "changed")
+			})
+
+			Convey("Then the line before the change should be kept", func() {
+				lines := strings.Split(w.String(), "\n")
+
+				So(lines[0], ShouldStartWith, "00000000 ")
+				So(lines[1], ShouldEqual, "*")
+				So(lines[2], ShouldStartWith, "00000038 ")
+				So(lines[3], ShouldStartWith, "00000040 ")
+				So(lines[3], ShouldContainSubstring, "  |  ")
+			})
+		})
+
+		Convey("When diff the inputs with color", func() {
+			var w strings.Builder
+
+			_, _ = hexdump.Diff(strings.NewReader(a), strings.NewReader(b), hexdump.Skip(60), hexdump.LineWidth(16),
+				hexdump.AlwaysColor, hexdump.Output(&w))
+
+			Convey("Then the differing bytes should be highlighted with the theme colors", func() {
+				So(w.String(), ShouldContainSubstring, " "+hexdump.DefaultTheme.Old.Sprint("6f"))
+				So(w.String(), ShouldContainSubstring, " "+hexdump.DefaultTheme.New.Sprint("6e"))
+				So(w.String(), ShouldContainSubstring, hexdump.DefaultTheme.New.Sprint("new"))
+				So(w.String(), ShouldNotContainSubstring, hexdump.DefaultTheme.Old.Sprint("00"))
+			})
+		})
+
+		Convey("When diff the inputs with inserted and deleted bytes aligned", func() {
+			var w strings.Builder
+
+			var b strings.Builder
+
+			for i := range 2048 {
+				fmt.Fprintf(&b, "%07d,", i)
+			}
+
+			old := b.String()
+			new := old[:5000] + "inserted" + old[5000:9000] + old[9100:]
+
+			changes, err := hexdump.Diff(strings.NewReader(old), strings.NewReader(new), hexdump.Align(true),
+				hexdump.LineWidth(16), hexdump.Output(&w))
+
+			Convey("Then the inputs should be resynchronized after the changes", func() {
+				So(err, ShouldBeNil)
+				So(changes, ShouldResemble, []hexdump.Change{{5000, 5000, 5000, 5008}, {9000, 9100, 9008, 9008}})
+				So(changes[0].Kind(), ShouldEqual, "inserted")
+				So(changes[1].Kind(), ShouldEqual, "deleted")
+				So(w.String(), ShouldContainSubstring, "|0001126,0001127,|  <\n")
+			})
+		})
+
+		Convey("When diff the different inputs aligned", func() {
+			var w strings.Builder
+
+			changes, err := hexdump.Diff(strings.NewReader("abcdefgh"), strings.NewReader("12345"),
+				hexdump.Align(true), hexdump.Output(&w))
+
+			Convey("Then the bytes without any anchor should be replaced", func() {
+				So(err, ShouldBeNil)
+				So(changes, ShouldResemble, []hexdump.Change{{0, 8, 0, 5}})
+				So(changes[0].Kind(), ShouldEqual, "changed")
+			})
+		})
+	})
+}
@@ -33,6 +33,9 @@ func Bytes(b []byte, x ...Option) (err error) {
 		b = b[:d.Length]
 	}
 
+	d.size = int64(len(b))
+	d.end = d.Start + d.off + d.size
+
 	if _, err = d.Write(b); err != nil {
 		return
 	}
@@ -89,7 +92,15 @@ func Stream(r io.Reader, x ...Option) (err error) {
 		}
 	}
 
+	if n := inputSize(r); n > 0 {
+		d.end = d.Start + n
+	}
+
 	if d.Length > 0 {
+		if end := d.Start + d.off + d.Length; d.end == 0 || end < d.end {
+			d.end = end
+		}
+
 		r = &io.LimitedReader{R: r, N: d.Length}
 	}
 
@@ -123,30 +134,38 @@ func Seq[T any](i iter.Seq[T], x ...Option) (err error) {
 
 // Dumper converts the binary content into a readable ASCII table.
 type Dumper struct {
-	b    bytes.Buffer
-	f    *Formatter
-	once sync.Once
-	off  int64
+	b         bytes.Buffer
+	f         LineFormatter
+	once      sync.Once
+	off       int64
+	last      []byte
+	squeezing bool
+	size      int64 // The size of the input if known, otherwise zero.
+	end       int64 // The offset of the end of the input if known, otherwise zero.
 
 	// The output stream, the default is [os.Stdout].
 	Output io.Writer
 
-	// The number of bytes per line, the default is 16.
+	// The number of bytes per line, the default is 16 for table, 30 for plain hex and 12 for source code.
 	LineWidth int
 
 	// The color mode of the line, the default is [ColorAuto].
 	Color ColorMode
 
-	// The color theme of the line, the default is [DefaultTheme].
+	// The color theme of the line, the default is the theme of [ThemeEnv] if it is set, otherwise [DefaultTheme].
 	Theme *ColorTheme
 
 	// The display style of the line, the default is [StyleCanonical].
 	Style DisplayStyle
 
+	// The display styles of the rows stacked beneath the offset of each line instead of the single Style,
+	// only the first row has the char column.
+	Styles []DisplayStyle
+
 	// The byte order used to read the data group, the default is [binary.NativeEndian].
 	ByteOrder binary.ByteOrder
 
-	// The start offset of the binary content.
+	// The start offset of the binary content, which is the load address of Intel HEX and S-records.
 	Start int64
 
 	// Skip offset bytes from the beginning of the input.
@@ -154,6 +173,55 @@ type Dumper struct {
 
 	// Interpret only length bytes of input.
 	Length int64
+
+	// The output mode, the default is [ModeTable].
+	Mode OutputMode
+
+	// The name of the input, which is used to derive the variable name of source code and the S-record header.
+	Name string
+
+	// The format of the offset column, the width grows to display the end offset of the input if its size is known.
+	Offset OffsetFormat
+
+	// The format of the cells in the content column and the delimiters of the char column.
+	Cells CellFormat
+
+	// The highlighted ranges of bytes at the offsets of the table, the later ranges take precedence over the earlier ones.
+	Highlights []HighlightRange
+
+	// How the labels of the highlighted ranges are displayed, the default is [LabelNone].
+	Labels LabelMode
+
+	// Display all input data, otherwise identical consecutive lines are replaced with a line containing a single '*'.
+	Verbose bool
+
+	// Align the inputs of a diff with an edit script, so the inserted and deleted bytes are displayed as gaps,
+	// otherwise the bytes at the same offsets are compared.
+	Align bool
+
+	// The format strings applied to each block of the input instead of the output mode,
+	// the line width is the block size of the layout.
+	Layout *FormatLayout
+
+	// The custom formatter of the lines instead of the output mode and layout.
+	Formatter LineFormatter
+}
+
+// LineFormatter formats the lines of binary content.
+//
+// A LineFormatter with a FormatSqueeze() error method is called in place of identical consecutive lines,
+// unless [Dumper.Verbose] is set.
+type LineFormatter interface {
+	// FormatLine formats a line of binary content at the offset, the first skip bytes of the line are absent.
+	FormatLine(off int64, skip int, buf []byte) error
+
+	// FormatEnd formats the end of the output at the offset of the end of the input.
+	FormatEnd(off int64) error
+}
+
+// squeezer formats a line in place of identical consecutive lines.
+type squeezer interface {
+	FormatSqueeze() error
 }
 
 // New returns a new [Dumper] with the provided options.
@@ -167,7 +235,7 @@ func New(x ...Option) (d *Dumper) {
 	return
 }
 
-// Flush dump any buffered data to the underlying [io.Writer].
+// Flush dump any buffered data and the end of the output to the underlying [io.Writer].
 func (d *Dumper) Flush() (err error) {
 	d.init()
 
@@ -175,7 +243,9 @@ func (d *Dumper) Flush() (err error) {
 		return err
 	}
 
-	return
+	d.squeezing = false
+
+	return d.f.FormatEnd(d.Start + d.off)
 }
 
 // Write writes the contents of p into the buffer.
@@ -239,11 +309,7 @@ func (d *Dumper) WriteString(s string) (count int, err error) {
 func (d *Dumper) flushLines(all bool) (err error) {
 	d.once.Do(d.init)
 
-	width := int64(d.LineWidth)
-	off := d.Start + d.off
-	skip := off % width
-
-	for int(skip)+d.b.Len() >= d.LineWidth {
+	for int(d.lineSkip())+d.b.Len() >= d.LineWidth {
 		if err = d.flushLine(false); err != nil {
 			return
 		}
@@ -258,11 +324,20 @@ func (d *Dumper) flushLines(all bool) (err error) {
 	return
 }
 
+// lineSkip returns the number of bytes skipped at the beginning of the current line,
+// only the lines of table are aligned to the line width.
+func (d *Dumper) lineSkip() int64 {
+	if _, ok := d.f.(*Formatter); !ok {
+		return 0
+	}
+
+	return (d.Start + d.off) % int64(d.LineWidth)
+}
+
 func (d *Dumper) flushLine(all bool) (err error) {
 	width := int64(d.LineWidth)
-	off := d.Start + d.off
-	start := off / width * width
-	skip := off % width
+	skip := d.lineSkip()
+	start := d.Start + d.off - skip
 
 	length := width - skip
 	if all {
@@ -277,8 +352,30 @@ func (d *Dumper) flushLine(all bool) (err error) {
 		return
 	}
 
-	if err = d.f.FormatLine(start, int(skip), b); err != nil {
-		return
+	full := skip == 0 && length == width
+	sq, squeezable := d.f.(squeezer)
+
+	// The highlighted lines are never squeezed, so their colors and labels are displayed.
+	if full && squeezable && !d.Verbose && bytes.Equal(b, d.last) && !d.highlighted(start, len(b)) {
+		if !d.squeezing {
+			if err = sq.FormatSqueeze(); err != nil {
+				return
+			}
+
+			d.squeezing = true
+		}
+	} else {
+		if err = d.f.FormatLine(start, int(skip), b); err != nil {
+			return
+		}
+
+		d.squeezing = false
+	}
+
+	if full {
+		d.last = b
+	} else {
+		d.last = nil
 	}
 
 	d.off += int64(n)
@@ -287,11 +384,74 @@ func (d *Dumper) flushLine(all bool) (err error) {
 }
 
 func (d *Dumper) init() {
+	d.setDefaults()
+
+	initColor(d.Output, d.Color)
+
+	if d.f == nil {
+		d.f = d.Formatter
+	}
+
+	if d.f == nil {
+		d.f = d.newFormatter(bufio.NewWriter(d.Output))
+	}
+}
+
+func (d *Dumper) newFormatter(w *bufio.Writer) LineFormatter {
+	if d.Layout != nil {
+		return newLayoutFormatter(w, d.Layout, d.ByteOrder)
+	}
+
+	switch d.Mode {
+	case ModePlain:
+		return &plainEmitter{w}
+
+	case ModeInclude:
+		return &includeEmitter{w: w, name: identifier(d.Name)}
+
+	case ModeGo, ModeRust, ModePython, ModeJava:
+		name := identifier(d.Name)
+		if name == "" {
+			name = defaultVarName
+		}
+
+		return &sourceEmitter{w: w, lang: languages[d.Mode], name: name, size: d.size}
+
+	case ModeIHex:
+		return &ihexEmitter{w: w}
+
+	case ModeS19, ModeS28, ModeS37:
+		name := d.Name
+		if name == "-" {
+			name = ""
+		}
+
+		return &srecEmitter{w: w, addr: int(d.Mode-ModeS19) + 2, entry: d.Start, name: name}
+
+	default:
+		return &Formatter{
+			Writer:       w,
+			ColorTheme:   d.Theme,
+			DisplayStyle: d.Style,
+			Styles:       d.Styles,
+			ByteOrder:    d.ByteOrder,
+			LineWidth:    d.LineWidth,
+			OffsetFormat: d.Offset.fit(d.end),
+			Cells:        d.Cells,
+			Highlights:   d.Highlights,
+			Labels:       d.Labels,
+		}
+	}
+}
+
+func (d *Dumper) setDefaults() {
 	if d.Output == nil {
 		d.Output = os.Stdout
 	}
 
-	initColor(d.Output, d.Color)
+	if d.Theme == nil {
+		d.Theme = envTheme()
+	}
 
 	if d.Theme == nil {
 		d.Theme = &DefaultTheme
@@ -301,11 +461,15 @@ func (d *Dumper) init() {
 		d.ByteOrder = binary.NativeEndian
 	}
 
-	if d.LineWidth == 0 {
-		d.LineWidth = DefaultLineWidth
+	if d.Style == nil {
+		d.Style = StyleCanonical
 	}
 
-	if d.f == nil {
-		d.f = &Formatter{bufio.NewWriter(d.Output), d.Theme, d.Style, d.ByteOrder, d.LineWidth}
+	if d.Layout != nil {
+		d.LineWidth = d.Layout.BlockSize()
+	}
+
+	if d.LineWidth == 0 {
+		d.LineWidth = d.Mode.lineWidth()
 	}
 }
@@ -5,9 +5,11 @@ import (
 	"encoding/binary"
 	"fmt"
 	"hash/fnv"
+	"math"
 	"strings"
 	"testing"
 
+	"github.com/fatih/color"
 	. "github.com/smartystreets/goconvey/convey"
 
 	"github.com/flier/hexdump"
@@ -132,6 +134,40 @@ func ExampleTwoBytesDec() {
 	// 00000000    00123   00456   00789                                          |{.....          |
 }
 
+func ExampleOneByteBinary() {
+	_ = hexdump.String("Hello", hexdump.OneByteBinary, hexdump.LineWidth(4))
+	// Output:
+	// 00000000  01001000 01100101 01101100 01101100  |Hell|
+	// 00000004  01101111                             |o   |
+}
+
+func ExampleBinaryStyle() {
+	s := hexdump.BinaryStyle{Nibbles: true, LSBFirst: true}
+
+	_ = hexdump.String("Hello, World!", hexdump.Style(s), hexdump.LineWidth(10))
+	// Output:
+	// 00000000  0001_0010 1010_0110 0011_0110 0011_0110 1111_0110 0011_0100 0000_0100 1110_1010  1111_0110 0100_1110  |Hello, Wor|
+	// 0000000a  0011_0110 0010_0110 1000_0100                                                                         |ld!       |
+}
+
+func ExampleOneByteDec() {
+	_ = hexdump.Bytes([]byte{0, 1, 127, 128, 255}, hexdump.OneByteDec)
+	// Output:
+	// 00000000  000 001 127 128 255                                               |.....           |
+}
+
+func ExampleOneByteSignedDec() {
+	_ = hexdump.Bytes([]byte{0, 1, 127, 128, 255}, hexdump.OneByteSignedDec)
+	// Output:
+	// 00000000     0    1  127 -128   -1                                                          |.....           |
+}
+
+func ExampleTwoBytesSignedDec() {
+	_ = hexdump.Slices([]int16{-32768, -257, -1, 0, 123, 32767}, hexdump.TwoBytesSignedDec)
+	// Output:
+	// 00000000   -32768    -257      -1       0     123   32767                  |........{...    |
+}
+
 func ExampleTwoBytesHex() {
 	_ = hexdump.Slices([]uint16{0x0123, 0x4567, 0x89ab, 0xcdef}, hexdump.TwoBytesHex, hexdump.LittleEndian)
 	// Output:
@@ -144,6 +180,145 @@ func ExampleTwoBytesOctal() {
 	// 00000000   000123  000456  000777                                          |S.....          |
 }
 
+func ExampleFourBytesHex() {
+	_ = hexdump.Slices([]uint32{0x01234567, 0x89abcdef}, hexdump.FourBytesHex)
+	// Output:
+	// 00000000     01234567    89abcdef                          |gE#.....        |
+}
+
+func ExampleFourBytesSignedDec() {
+	_ = hexdump.Bytes([]byte{0xff, 0xff, 0xff, 0xfe, 0x00, 0x00, 0x01}, hexdump.FourBytesSignedDec, hexdump.BigEndian)
+	// Output:
+	// 00000000           -2           1                          |.......         |
+}
+
+func ExampleEightBytesDec() {
+	_ = hexdump.Slices([]uint64{1, 1 << 63}, hexdump.EightBytesDec)
+	// Output:
+	// 00000000    00000000000000000001   09223372036854775808  |................|
+}
+
+func ExampleEightBytesSignedDec() {
+	_ = hexdump.Slices([]int64{-1, 1 << 62}, hexdump.EightBytesSignedDec)
+	// Output:
+	// 00000000                      -1    4611686018427387904  |...............@|
+}
+
+func ExampleEightBytesOctal() {
+	_ = hexdump.Slices([]uint64{0o1234567}, hexdump.EightBytesOctal)
+	// Output:
+	// 00000000  0000000000000001234567                         |w9......        |
+}
+
+func ExampleFloat16() {
+	_ = hexdump.Slices([]uint16{0x3c00, 0xc000, 0x3555, 0x7bff, 0x0001, 0x7c00, 0xfc00, 0x7e00}, hexdump.Float16)
+	// Output:
+	// 00000000            1          -2     0.33325       65504  5.9605e-08        +Inf        -Inf         NaN  |.<..U5.{...|...~|
+}
+
+func ExampleBFloat16() {
+	_ = hexdump.Slices([]uint16{0x3f80, 0xc049, 0x7f80, 0x7fc0}, hexdump.BFloat16)
+	// Output:
+	// 00000000           1     -3.141       +Inf        NaN                                              |.?I.....        |
+}
+
+func ExampleFloat32() {
+	_ = hexdump.Slices([]float32{1.5, -2.25, math.Pi, float32(math.Inf(1))}, hexdump.Float32)
+	// Output:
+	// 00000000              1.5           -2.25       3.1415927            +Inf  |...?......I@....|
+}
+
+func ExampleFloat64() {
+	_ = hexdump.Slices([]float64{math.E, -1e-300}, hexdump.Float64)
+	// Output:
+	// 00000000         2.718281828459045                  -1e-300  |iW.....@Y....n..|
+}
+
+func ExampleFloat32_partial() {
+	_ = hexdump.Bytes([]byte{0x00, 0x00, 0xc0, 0x3f, 0xde, 0xad, 0xbe}, hexdump.Float32, hexdump.LittleEndian)
+	// Output:
+	// 00000000              1.5          deadbe                                  |...?...         |
+}
+
+func ExampleFloatStyle() {
+	s := hexdump.FloatStyle{Kind: hexdump.KindFloat32, Precision: 3, Payload: true}
+
+	_ = hexdump.Slices([]uint32{0x40490fdb, 0x7fc00001, 0xff800123, 0x7f800000}, hexdump.Style(s))
+	// Output:
+	// 00000000             3.14       qNaN(0x1)    -sNaN(0x123)            +Inf  |..I@....#.......|
+}
+
+func ExampleGroupStyle() {
+	signed := &hexdump.GroupStyle{
+		Size:  1,
+		Width: 4,
+		Render: func(b []byte, _ binary.ByteOrder) string {
+			return fmt.Sprintf("%4d", int8(b[0]))
+		},
+	}
+
+	_ = hexdump.Bytes([]byte{0x01, 0x7f, 0x80, 0xff}, hexdump.Style(signed), hexdump.LineWidth(8))
+	// Output:
+	// 00000000     1  127 -128   -1                      |....    |
+}
+
+func ExampleRegisterStyle() {
+	hexdump.RegisterStyle("upper-hex", &hexdump.GroupStyle{
+		Size:  1,
+		Width: 2,
+		Render: func(b []byte, _ binary.ByteOrder) string {
+			return fmt.Sprintf("%02X", b[0])
+		},
+	})
+
+	s, _ := hexdump.LookupStyle("upper-hex")
+
+	_ = hexdump.String("Hello, World!", hexdump.Style(s))
+	// Output:
+	// 00000000  48 65 6C 6C 6F 2C 20 57  6F 72 6C 64 21           |Hello, World!   |
+}
+
+func TestLookupStyle(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given the names of the builtin display styles", t, func() {
+		for _, tc := range []struct {
+			name  string
+			style hexdump.DisplayStyle
+		}{
+			{"canonical", hexdump.StyleCanonical},
+			{"one-byte-char", hexdump.StyleOneByteChar},
+			{"one-byte-hex", hexdump.StyleOneByteHex},
+			{"one-byte-octal", hexdump.StyleOneByteOctal},
+			{"one-byte-dec", hexdump.StyleOneByteDec},
+			{"one-byte-signed-dec", hexdump.StyleOneByteSignedDec},
+			{"one-byte-binary", hexdump.StyleOneByteBinary},
+			{"two-bytes-dec", hexdump.StyleTwoBytesDec},
+			{"two-bytes-signed-dec", hexdump.StyleTwoBytesSignedDec},
+			{"two-bytes-hex", hexdump.StyleTwoBytesHex},
+			{"two-bytes-octal", hexdump.StyleTwoBytesOctal},
+			{"four-bytes-dec", hexdump.StyleFourBytesDec},
+			{"four-bytes-signed-dec", hexdump.StyleFourBytesSignedDec},
+			{"four-bytes-hex", hexdump.StyleFourBytesHex},
+			{"four-bytes-octal", hexdump.StyleFourBytesOctal},
+			{"eight-bytes-dec", hexdump.StyleEightBytesDec},
+			{"eight-bytes-signed-dec", hexdump.StyleEightBytesSignedDec},
+			{"eight-bytes-hex", hexdump.StyleEightBytesHex},
+			{"eight-bytes-octal", hexdump.StyleEightBytesOctal},
+		} {
+			Convey("When lookup "+tc.name, func() {
+				s, ok := hexdump.LookupStyle(tc.name)
+
+				Convey("Then the style should be registered", func() {
+					So(ok, ShouldBeTrue)
+					So(s, ShouldEqual, tc.style)
+					So(hexdump.StyleNames(), ShouldContain, tc.name)
+				})
+			})
+		}
+	})
+}
+
 func ExampleLittleEndian() {
 	_ = hexdump.Bytes([]byte{1, 2, 3, 4, 5, 6, 7, 8}, hexdump.TwoBytesHex, hexdump.LittleEndian)
 	// Output:
@@ -193,6 +368,105 @@ func ExampleLength() {
 	// 00000000  48 65 6c 6c 6f                                    |Hello           |
 }
 
+func ExampleVerbose() {
+	b := bytes.Repeat([]byte("Hello, World!..."), 4)
+
+	_ = hexdump.Bytes(b, hexdump.Verbose(true))
+	// Output:
+	// 00000000  48 65 6c 6c 6f 2c 20 57  6f 72 6c 64 21 2e 2e 2e  |Hello, World!...|
+	// 00000010  48 65 6c 6c 6f 2c 20 57  6f 72 6c 64 21 2e 2e 2e  |Hello, World!...|
+	// 00000020  48 65 6c 6c 6f 2c 20 57  6f 72 6c 64 21 2e 2e 2e  |Hello, World!...|
+	// 00000030  48 65 6c 6c 6f 2c 20 57  6f 72 6c 64 21 2e 2e 2e  |Hello, World!...|
+}
+
+func ExampleDumper_squeeze() {
+	b := append(make([]byte, 64), "Hello, World!"...)
+
+	_ = hexdump.Bytes(b)
+	_ = hexdump.Bytes(make([]byte, 48))
+	// Output:
+	// 00000000  00 00 00 00 00 00 00 00  00 00 00 00 00 00 00 00  |................|
+	// *
+	// 00000040  48 65 6c 6c 6f 2c 20 57  6f 72 6c 64 21           |Hello, World!   |
+	// 00000000  00 00 00 00 00 00 00 00  00 00 00 00 00 00 00 00  |................|
+	// *
+	// 00000030
+}
+
+func ExampleStyles() {
+	_ = hexdump.String("Hello, Gophers!\n",
+		hexdump.Styles(hexdump.StyleCanonical, hexdump.StyleOneByteDec, hexdump.StyleTwoBytesHex), hexdump.LittleEndian)
+	// Output:
+	// 00000000   48  65  6c  6c  6f  2c  20  47   6f  70  68  65  72  73  21  0a  |Hello, Gophers!.|
+	//           072 101 108 108 111 044 032 071  111 112 104 101 114 115 033 010
+	//              6548    6c6c    2c6f    4720     706f    6568    7372    0a21
+}
+
+func ExampleStyles_partial() {
+	_ = hexdump.String("Hello, Gophers!",
+		hexdump.Styles(hexdump.StyleTwoBytesHex, hexdump.StyleCanonical), hexdump.LittleEndian)
+	// Output:
+	// 00000000     6548    6c6c    2c6f    4720     706f    6568    7372    0021  |Hello, Gophers! |
+	//            48  65  6c  6c  6f  2c  20  47   6f  70  68  65  72  73  21
+}
+
+func ExampleOffset() {
+	_ = hexdump.String("Hello, World!", hexdump.Offset(hexdump.OffsetFormat{Width: 4, Upper: true, Prefix: "0x"}), hexdump.Start(0xabc))
+	// Output:
+	// 0x0AB0                                       48 65 6c 6c  |            Hell|
+	// 0x0AC0  6f 2c 20 57 6f 72 6c 64  21                       |o, World!       |
+}
+
+func ExampleOffset_grow() {
+	_ = hexdump.String("Hello, World!", hexdump.DecOffset, hexdump.Start(99999990))
+	// Output:
+	// 099999984                    48 65  6c 6c 6f 2c 20 57 6f 72  |      Hello, Wor|
+	// 100000000  6c 64 21                                          |ld!             |
+}
+
+func ExampleNoOffset() {
+	_ = hexdump.String("Hello, World!", hexdump.NoOffset)
+	// Output:
+	//  48 65 6c 6c 6f 2c 20 57  6f 72 6c 64 21           |Hello, World!   |
+}
+
+func ExampleCells() {
+	_ = hexdump.String("Hello, World!", hexdump.Cells(hexdump.CellFormat{Group: 4, Upper: true, Delims: [2]string{"[", "]"}}))
+	// Output:
+	// 00000000  48 65 6C 6C  6F 2C 20 57  6F 72 6C 64  21           [Hello, World!   ]
+}
+
+func ExampleCells_noSep() {
+	_ = hexdump.String("Hello, World!", hexdump.Cells(hexdump.CellFormat{NoSep: true}))
+	// Output:
+	// 00000000  48656c6c6f2c20576f726c6421        |Hello, World!   |
+}
+
+type checksumFormatter struct{}
+
+func (checksumFormatter) FormatLine(off int64, _ int, buf []byte) error {
+	h := fnv.New32a()
+	_, _ = h.Write(buf)
+
+	fmt.Printf("%04x %08x\n", off, h.Sum32())
+
+	return nil
+}
+
+func (checksumFormatter) FormatEnd(off int64) error {
+	fmt.Printf("%04x\n", off)
+
+	return nil
+}
+
+func ExampleLineFormat() {
+	_ = hexdump.String("Hello, World!", hexdump.LineFormat(checksumFormatter{}), hexdump.LineWidth(8))
+	// Output:
+	// 0000 95c7a818
+	// 0008 175ee9af
+	// 000d
+}
+
 func TestAlwaysColor(t *testing.T) {
 	t.Parallel()
 
@@ -213,5 +487,28 @@ func TestAlwaysColor(t *testing.T) {
 						theme.Chars.Sprint("Hello, World!   ")+"|\n")
 			})
 		})
+
+		Convey("When dump it with the byte class color theme", func() {
+			var b strings.Builder
+
+			_ = hexdump.String("Hi\x00 \xff", hexdump.AlwaysColor, hexdump.Theme(&hexdump.ByteClassTheme), hexdump.Output(&b))
+
+			Convey("Then the cells and chars should be colored by the classes of bytes", func() {
+				theme := hexdump.ByteClassTheme
+
+				var content strings.Builder
+
+				theme.Content.SetWriter(&content)
+
+				cell := func(c *color.Color, s string) string { return " " + c.Sprint(s) + content.String() }
+
+				So(b.String(), ShouldEqual,
+					theme.Offset.Sprint("00000000")+" "+
+						theme.Content.Sprint(cell(theme.Printable, "48")+cell(theme.Printable, "69")+
+							cell(theme.Null, "00")+cell(theme.Whitespace, "20")+cell(theme.High, "ff")+strings.Repeat(" ", 34))+"  |"+
+						theme.Printable.Sprint("Hi")+theme.Null.Sprint(".")+theme.Whitespace.Sprint(" ")+
+						theme.High.Sprint(".")+theme.Chars.Sprint(strings.Repeat(" ", 11))+"|\n")
+			})
+		})
 	})
 }
@@ -0,0 +1,257 @@
+package hexdump
+
+import (
+	"bufio"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode"
+)
+
+//go:generate go tool stringer -type=OutputMode -linecomment
+
+// OutputMode is the output format of the [Dumper].
+type OutputMode int //nolint:recvcheck
+
+const (
+	ModeTable   OutputMode = iota // table
+	ModePlain                     // plain
+	ModeInclude                   // include
+	ModeGo                        // go
+	ModeRust                      // rust
+	ModePython                    // python
+	ModeJava                      // java
+	ModeIHex                      // ihex
+	ModeS19                       // s19
+	ModeS28                       // s28
+	ModeS37                       // s37
+)
+
+func (m OutputMode) MarshalText() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+func (m *OutputMode) UnmarshalText(text []byte) (err error) {
+	for i := range len(_OutputMode_index) - 1 {
+		if strings.EqualFold(string(text), _OutputMode_name[_OutputMode_index[i]:_OutputMode_index[i+1]]) {
+			*m = OutputMode(i)
+
+			return nil
+		}
+	}
+
+	return fmt.Errorf("output mode %q, %w", text, os.ErrInvalid)
+}
+
+const (
+	plainLineWidth   = 30
+	includeLineWidth = 12
+)
+
+// lineWidth returns the default number of bytes per line of the output mode.
+func (m OutputMode) lineWidth() int {
+	switch m {
+	case ModePlain:
+		return plainLineWidth
+	case ModeInclude, ModeGo, ModeRust, ModePython, ModeJava:
+		return includeLineWidth
+	default:
+		return DefaultLineWidth
+	}
+}
+
+// plainEmitter writes the binary content as continuous plain hex, like `xxd -p`.
+type plainEmitter struct {
+	*bufio.Writer
+}
+
+func (e *plainEmitter) FormatLine(_ int64, _ int, buf []byte) error {
+	_, err := e.WriteString(hex.EncodeToString(buf) + "\n")
+
+	return errors.Join(err, e.Flush())
+}
+
+func (e *plainEmitter) FormatEnd(int64) error { return e.Flush() }
+
+// includeEmitter writes the binary content as a C include file, like `xxd -i`.
+//
+// Only the array elements are written if the variable name is empty.
+type includeEmitter struct {
+	w    *bufio.Writer
+	name string
+	n    int64
+}
+
+func (e *includeEmitter) FormatLine(_ int64, _ int, buf []byte) error {
+	switch {
+	case e.n > 0:
+		_, _ = e.w.WriteString(",\n")
+
+	case e.name != "":
+		_, _ = e.w.WriteString("unsigned char " + e.name + "[] = {\n")
+	}
+
+	_, _ = e.w.WriteString("  ")
+
+	for i, c := range buf {
+		if i > 0 {
+			_, _ = e.w.WriteString(", ")
+		}
+
+		_, _ = fmt.Fprintf(e.w, "0x%02x", c)
+	}
+
+	e.n += int64(len(buf))
+
+	return e.w.Flush()
+}
+
+func (e *includeEmitter) FormatEnd(int64) error {
+	if e.n > 0 {
+		_ = e.w.WriteByte('\n')
+	}
+
+	if e.name != "" {
+		if e.n == 0 {
+			_, _ = e.w.WriteString("unsigned char " + e.name + "[] = {\n")
+		}
+
+		_, _ = fmt.Fprintf(e.w, "};\nunsigned int %s_len = %d;\n", e.name, e.n)
+	}
+
+	return e.w.Flush()
+}
+
+// sourceEmitter writes the binary content as an array literal of source code.
+type sourceEmitter struct {
+	w    *bufio.Writer
+	lang *language
+	name string
+	size int64
+	n    int64
+}
+
+// language writes the parts of an array literal of source code.
+type language struct {
+	begin func(w io.Writer, name string, size int64)
+	line  func(w io.Writer, buf []byte)
+	end   func(w io.Writer, name string, n int64)
+}
+
+var languages = map[OutputMode]*language{
+	ModeGo: {
+		begin: func(w io.Writer, name string, _ int64) { _, _ = fmt.Fprintf(w, "var %s = []byte{\n", name) },
+		line:  elements("\t", "0x%02x,", " ", nil),
+		end:   func(w io.Writer, _ string, _ int64) { _, _ = io.WriteString(w, "}\n") },
+	},
+	ModeRust: {
+		begin: func(w io.Writer, name string, size int64) {
+			if size > 0 {
+				_, _ = fmt.Fprintf(w, "pub const %s: [u8; %d] = [\n", strings.ToUpper(name), size)
+			} else {
+				_, _ = fmt.Fprintf(w, "pub static %s: &[u8] = &[\n", strings.ToUpper(name))
+			}
+		},
+		line: elements("    ", "0x%02x,", " ", nil),
+		end:  func(w io.Writer, _ string, _ int64) { _, _ = io.WriteString(w, "];\n") },
+	},
+	ModePython: {
+		begin: func(w io.Writer, name string, _ int64) { _, _ = fmt.Fprintf(w, "%s = (\n", name) },
+		line: func(w io.Writer, buf []byte) {
+			_, _ = fmt.Fprintf(w, "    b\"%s\"\n", strings.Join(mapBytes(buf, "\\x%02x"), ""))
+		},
+		end: func(w io.Writer, _ string, n int64) {
+			if n == 0 {
+				_, _ = io.WriteString(w, "    b\"\"\n")
+			}
+
+			_, _ = io.WriteString(w, ")\n")
+		},
+	},
+	ModeJava: {
+		begin: func(w io.Writer, name string, _ int64) { _, _ = fmt.Fprintf(w, "byte[] %s = {\n", name) },
+		line: elements("    ", "0x%02x,", " ", func(c byte) string {
+			if c >= 0x80 {
+				return fmt.Sprintf("(byte) 0x%02x,", c)
+			}
+
+			return ""
+		}),
+		end: func(w io.Writer, _ string, _ int64) { _, _ = io.WriteString(w, "};\n") },
+	},
+}
+
+// elements returns a function to write a line of array elements,
+// the element is formatted with the format unless the override returns a non-empty string.
+func elements(indent, format, sep string, override func(c byte) string) func(io.Writer, []byte) {
+	return func(w io.Writer, buf []byte) {
+		elems := mapBytes(buf, format)
+
+		if override != nil {
+			for i, c := range buf {
+				if s := override(c); s != "" {
+					elems[i] = s
+				}
+			}
+		}
+
+		_, _ = io.WriteString(w, indent+strings.Join(elems, sep)+"\n")
+	}
+}
+
+func mapBytes(buf []byte, format string) []string {
+	s := make([]string, len(buf))
+
+	for i, c := range buf {
+		s[i] = fmt.Sprintf(format, c)
+	}
+
+	return s
+}
+
+func (e *sourceEmitter) FormatLine(_ int64, _ int, buf []byte) error {
+	if e.n == 0 {
+		e.lang.begin(e.w, e.name, e.size)
+	}
+
+	e.lang.line(e.w, buf)
+	e.n += int64(len(buf))
+
+	return e.w.Flush()
+}
+
+func (e *sourceEmitter) FormatEnd(int64) error {
+	if e.n == 0 {
+		e.lang.begin(e.w, e.name, e.size)
+	}
+
+	e.lang.end(e.w, e.name, e.n)
+
+	return e.w.Flush()
+}
+
+const defaultVarName = "data"
+
+// identifier derives a variable name from the name of the input, like `xxd -i`.
+func identifier(name string) string {
+	if name == "" || name == "-" {
+		return ""
+	}
+
+	id := strings.Map(func(r rune) rune {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			return r
+		}
+
+		return '_'
+	}, name)
+
+	if unicode.IsDigit(rune(id[0])) {
+		id = "__" + id
+	}
+
+	return id
+}
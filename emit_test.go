@@ -0,0 +1,75 @@
+package hexdump_test
+
+import (
+	"strings"
+
+	"github.com/flier/hexdump"
+)
+
+func ExamplePlain() {
+	_ = hexdump.String("Hello, World!\n", hexdump.Plain, hexdump.LineWidth(8))
+	// Output:
+	// 48656c6c6f2c2057
+	// 6f726c64210a
+}
+
+func ExampleInclude() {
+	_ = hexdump.String("Hello, World!\n", hexdump.Include)
+	// Output:
+	//   0x48, 0x65, 0x6c, 0x6c, 0x6f, 0x2c, 0x20, 0x57, 0x6f, 0x72, 0x6c, 0x64,
+	//   0x21, 0x0a
+}
+
+func ExampleName() {
+	_ = hexdump.String("Hello, World!\n", hexdump.Include, hexdump.Name("testdata/hello.txt"))
+	// Output:
+	// unsigned char testdata_hello_txt[] = {
+	//   0x48, 0x65, 0x6c, 0x6c, 0x6f, 0x2c, 0x20, 0x57, 0x6f, 0x72, 0x6c, 0x64,
+	//   0x21, 0x0a
+	// };
+	// unsigned int testdata_hello_txt_len = 14;
+}
+
+func ExampleGo() {
+	_ = hexdump.String("Hello, World!\n", hexdump.Go, hexdump.Name("hello"))
+	// Output:
+	// var hello = []byte{
+	// 	0x48, 0x65, 0x6c, 0x6c, 0x6f, 0x2c, 0x20, 0x57, 0x6f, 0x72, 0x6c, 0x64,
+	// 	0x21, 0x0a,
+	// }
+}
+
+func ExampleRust() {
+	_ = hexdump.String("Hello, World!\n", hexdump.Rust, hexdump.LineWidth(8))
+	// Output:
+	// pub const DATA: [u8; 14] = [
+	//     0x48, 0x65, 0x6c, 0x6c, 0x6f, 0x2c, 0x20, 0x57,
+	//     0x6f, 0x72, 0x6c, 0x64, 0x21, 0x0a,
+	// ];
+}
+
+func ExampleRust_stream() {
+	_ = hexdump.Stream(strings.NewReader("Hello, World!\n"), hexdump.Rust, hexdump.Name("hello.txt"))
+	// Output:
+	// pub static HELLO_TXT: &[u8] = &[
+	//     0x48, 0x65, 0x6c, 0x6c, 0x6f, 0x2c, 0x20, 0x57, 0x6f, 0x72, 0x6c, 0x64,
+	//     0x21, 0x0a,
+	// ];
+}
+
+func ExamplePython() {
+	_ = hexdump.String("Hello, World!\n", hexdump.Python, hexdump.Name("hello"), hexdump.LineWidth(8))
+	// Output:
+	// hello = (
+	//     b"\x48\x65\x6c\x6c\x6f\x2c\x20\x57"
+	//     b"\x6f\x72\x6c\x64\x21\x0a"
+	// )
+}
+
+func ExampleJava() {
+	_ = hexdump.Bytes([]byte{0x00, 0x7f, 0x80, 0xff}, hexdump.Java, hexdump.Name("bytes"))
+	// Output:
+	// byte[] bytes = {
+	//     0x00, 0x7f, (byte) 0x80, (byte) 0xff,
+	// };
+}
@@ -0,0 +1,328 @@
+package hexdump
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// FloatKind is the binary format of a floating-point number.
+type FloatKind int
+
+const (
+	KindFloat16  FloatKind = iota // IEEE-754 half precision.
+	KindBFloat16                  // Brain floating point.
+	KindFloat32                   // IEEE-754 single precision.
+	KindFloat64                   // IEEE-754 double precision.
+)
+
+type floatFormat struct {
+	size      int // The number of bytes.
+	mantissa  int // The number of mantissa bits.
+	exponent  int // The number of exponent bits.
+	digits    int // The number of significant digits to round-trip a value.
+	expDigits int // The maximum number of decimal exponent digits.
+}
+
+var floatFormats = [...]floatFormat{
+	KindFloat16:  {twoBytes, 10, 5, 5, 2},
+	KindBFloat16: {twoBytes, 7, 8, 4, 2},
+	KindFloat32:  {fourBytes, 23, 8, 9, 2},
+	KindFloat64:  {eightBytes, 52, 11, 17, 3},
+}
+
+// FloatStyle is a [DisplayStyle] which displays groups of bytes as floating-point numbers.
+type FloatStyle struct {
+	// The binary format of the numbers.
+	Kind FloatKind
+
+	// The number of significant digits, the default is the fewest digits that round-trip the value.
+	Precision int
+
+	// Display the sign, the quiet bit and the payload of NaN values, like -sNaN(0x1).
+	Payload bool
+}
+
+var (
+	StyleFloat16  DisplayStyle = FloatStyle{Kind: KindFloat16}  // Half precision floating-point display.
+	StyleBFloat16 DisplayStyle = FloatStyle{Kind: KindBFloat16} // Brain floating-point display.
+	StyleFloat32  DisplayStyle = FloatStyle{Kind: KindFloat32}  // Single precision floating-point display.
+	StyleFloat64  DisplayStyle = FloatStyle{Kind: KindFloat64}  // Double precision floating-point display.
+)
+
+// GroupSize returns the number of bytes rendered in one cell.
+func (s FloatStyle) GroupSize() int { return floatFormats[s.Kind].size }
+
+// CellWidth returns the width of a rendered cell.
+func (s FloatStyle) CellWidth() int {
+	f := floatFormats[s.Kind]
+
+	digits := f.digits
+	if s.Precision > 0 {
+		digits = s.Precision
+	}
+
+	// -d.ddde-XX
+	width := digits + len("-.e-") + f.expDigits
+
+	if s.Payload {
+		// -sNaN(0xXXX)
+		width = max(width, len("-sNaN(0x)")+(f.mantissa+2)/4)
+	}
+
+	return width
+}
+
+// FormatGroup renders a group of bytes as a cell,
+// a partial group at the end of the input is rendered as the hex digits of its bytes, since it isn't a number.
+func (s FloatStyle) FormatGroup(b []byte, order binary.ByteOrder) string {
+	if len(b) < s.GroupSize() {
+		return fmt.Sprintf("%*x", s.CellWidth(), b)
+	}
+
+	return fmt.Sprintf("%*s", s.CellWidth(), s.format(readUint(b, order)))
+}
+
+// PaddingCell returns the text of a cell without content.
+func (s FloatStyle) PaddingCell() string { return spaces(s.CellWidth()) }
+
+// ParseGroup parses a cell back into a group of n bytes.
+//
+// The value is only restored exactly when the cell has enough significant digits and the NaN payload.
+func (s FloatStyle) ParseGroup(cell string, n int, order binary.ByteOrder) ([]byte, error) {
+	if n < s.GroupSize() {
+		b, err := hex.DecodeString(strings.TrimSpace(cell))
+		if err == nil && len(b) != n {
+			err = fmt.Errorf("partial group %q, %w", cell, strconv.ErrSyntax)
+		}
+
+		return b, err
+	}
+
+	bits, err := s.parse(strings.TrimSpace(cell))
+	if err != nil {
+		return nil, err
+	}
+
+	return putUint(bits, n, order), nil
+}
+
+func (s FloatStyle) format(bits uint64) string {
+	f := floatFormats[s.Kind]
+
+	var v float64
+
+	switch s.Kind {
+	case KindFloat16:
+		v = float16(uint16(bits)) //nolint:gosec
+	case KindBFloat16:
+		v = float64(math.Float32frombits(uint32(bits) << 16)) //nolint:gosec
+	case KindFloat32:
+		v = float64(math.Float32frombits(uint32(bits))) //nolint:gosec
+	case KindFloat64:
+		v = math.Float64frombits(bits)
+	}
+
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+
+	case math.IsInf(v, -1):
+		return "-Inf"
+
+	case math.IsNaN(v):
+		if !s.Payload {
+			return "NaN"
+		}
+
+		return f.formatNaN(bits)
+	}
+
+	bitSize := 64
+	if f.size < eightBytes {
+		bitSize = 32
+	}
+
+	prec := s.Precision
+	if prec <= 0 {
+		prec = -1
+
+		if s.Kind == KindFloat16 || s.Kind == KindBFloat16 {
+			prec = f.digits
+		}
+	}
+
+	return strconv.FormatFloat(v, 'g', prec, bitSize)
+}
+
+func (f floatFormat) formatNaN(bits uint64) string {
+	var sign string
+
+	if bits>>(f.mantissa+f.exponent)&1 == 1 {
+		sign = "-"
+	}
+
+	quiet := uint64(1) << (f.mantissa - 1)
+	payload := bits & (quiet - 1)
+
+	kind := "s"
+	if bits&quiet != 0 {
+		kind = "q"
+	}
+
+	return fmt.Sprintf("%s%sNaN(0x%x)", sign, kind, payload)
+}
+
+func (s FloatStyle) parse(text string) (uint64, error) {
+	f := floatFormats[s.Kind]
+
+	signBit := uint64(1) << (f.mantissa + f.exponent)
+	infBits := (uint64(1)<<f.exponent - 1) << f.mantissa
+	quietBit := uint64(1) << (f.mantissa - 1)
+
+	switch text {
+	case "+Inf":
+		return infBits, nil
+
+	case "-Inf":
+		return signBit | infBits, nil
+
+	case "NaN":
+		return infBits | quietBit, nil
+	}
+
+	if m := nanPayload.FindStringSubmatch(text); m != nil {
+		payload, err := strconv.ParseUint(m[3], 16, f.mantissa-1)
+		if err != nil {
+			return 0, fmt.Errorf("NaN %q, %w", text, err)
+		}
+
+		bits := infBits | payload
+
+		if m[1] == "-" {
+			bits |= signBit
+		}
+
+		if m[2] == "q" {
+			bits |= quietBit
+		}
+
+		return bits, nil
+	}
+
+	bitSize := 64
+	if f.size < eightBytes {
+		bitSize = 32
+	}
+
+	v, err := strconv.ParseFloat(text, bitSize)
+	if err != nil {
+		return 0, err
+	}
+
+	switch s.Kind {
+	case KindFloat16:
+		return uint64(toFloat16(v)), nil
+	case KindBFloat16:
+		return uint64(toBFloat16(float32(v))), nil
+	case KindFloat32:
+		return uint64(math.Float32bits(float32(v))), nil
+	default:
+		return math.Float64bits(v), nil
+	}
+}
+
+var nanPayload = regexp.MustCompile(`^(-?)([qs])NaN\(0x([0-9a-f]+)\)$`)
+
+// float16 converts an IEEE-754 half precision number to float64.
+func float16(h uint16) float64 {
+	const (
+		mantissaBits = 10
+		exponentBias = 15
+		exponentMask = 0x1f
+		mantissaMask = 0x3ff
+	)
+
+	sign := 1.0
+	if h>>15 != 0 {
+		sign = -1
+	}
+
+	exp := int(h>>mantissaBits) & exponentMask
+	mant := float64(h & mantissaMask)
+
+	switch exp {
+	case 0:
+		return sign * math.Ldexp(mant, 1-exponentBias-mantissaBits)
+
+	case exponentMask:
+		if mant == 0 {
+			return math.Inf(int(sign))
+		}
+
+		return math.NaN()
+
+	default:
+		return sign * math.Ldexp(mant+(1<<mantissaBits), exp-exponentBias-mantissaBits)
+	}
+}
+
+// toFloat16 converts a float64 to the nearest IEEE-754 half precision number.
+func toFloat16(v float64) uint16 {
+	const (
+		mantissaBits = 10
+		exponentBias = 15
+		maxExponent  = 15
+		minExponent  = -14
+		infBits      = 0x7c00
+	)
+
+	var sign uint16
+
+	if math.Signbit(v) {
+		sign, v = 0x8000, -v
+	}
+
+	switch {
+	case math.IsNaN(v):
+		return sign | infBits | 1<<(mantissaBits-1)
+	case math.IsInf(v, 0):
+		return sign | infBits
+	case v == 0:
+		return sign
+	}
+
+	frac, exp := math.Frexp(v)
+	exp--
+
+	if exp < minExponent {
+		// a subnormal number rounded up to 1<<mantissaBits becomes the smallest normal number.
+		return sign | uint16(math.RoundToEven(math.Ldexp(v, mantissaBits-minExponent)))
+	}
+
+	mant := math.RoundToEven(math.Ldexp(frac, mantissaBits+1))
+	if mant == 1<<(mantissaBits+1) {
+		mant, exp = 1<<mantissaBits, exp+1
+	}
+
+	if exp > maxExponent {
+		return sign | infBits
+	}
+
+	return sign | uint16(exp+exponentBias)<<mantissaBits | uint16(mant)&(1<<mantissaBits-1) //nolint:gosec
+}
+
+// toBFloat16 converts a float32 to the nearest brain floating-point number.
+func toBFloat16(v float32) uint16 {
+	bits := math.Float32bits(v)
+
+	if math.IsNaN(float64(v)) {
+		return uint16(bits>>16) | 0x40
+	}
+
+	return uint16((bits + 0x7fff + (bits>>16)&1) >> 16)
+}
@@ -0,0 +1,74 @@
+package hexdump
+
+import (
+	"fmt"
+)
+
+// Formattable is a byte slice dumped into a readable ASCII table by the verbs of the fmt package.
+//
+// The verbs select the display style:
+//
+//   - %v, %s and %x display the canonical hex+ASCII table;
+//   - %o displays the one-byte octal table;
+//   - %d displays the one-byte decimal table.
+//
+// The bytes are colored unless the NO_COLOR environment variable is set, the terminal is dumb,
+// or the '+' flag is set like "%+v", and the width like "%8x" is the number of bytes per line.
+//
+// The options of [Of] take precedence over the defaults, like the canonical style of %v and the color of the terminal,
+// while the style of %o and %d, the '+' flag and the width take precedence over the options.
+// If the dump fails, the error is written like "%!v(ERROR=...)" after the partial output.
+type Formattable struct {
+	b    []byte
+	opts []Option
+}
+
+// Of returns a [Formattable] of the byte slice dumped with the options.
+func Of(b []byte, x ...Option) Formattable {
+	return Formattable{b, x}
+}
+
+// Format implements [fmt.Formatter].
+func (f Formattable) Format(s fmt.State, verb rune) {
+	var style DisplayStyle
+
+	switch verb {
+	case 'v', 's', 'x':
+	case 'o':
+		style = StyleOneByteOctal
+	case 'd':
+		style = StyleOneByteDec
+	default:
+		_, _ = fmt.Fprintf(s, "%%!%c(hexdump.Formattable=%d bytes)", verb, len(f.b))
+
+		return
+	}
+
+	color := ColorAlways
+	if noColorIsSet() || termIsDumb() {
+		color = ColorNever
+	}
+
+	opts := append([]Option{Color(color), Style(StyleCanonical)}, f.opts...)
+
+	if style != nil {
+		opts = append(opts, Style(style))
+	}
+
+	if s.Flag('+') {
+		opts = append(opts, NeverColor)
+	}
+
+	if width, ok := s.Width(); ok {
+		opts = append(opts, LineWidth(width))
+	}
+
+	if err := Bytes(f.b, append(opts, Output(s))...); err != nil {
+		_, _ = fmt.Fprintf(s, "%%!%c(ERROR=%v)", verb, err)
+	}
+}
+
+// String implements [fmt.Stringer], it returns the canonical hex+ASCII table without color.
+func (f Formattable) String() string {
+	return fmt.Sprintf("%+v", f)
+}
@@ -0,0 +1,102 @@
+package hexdump_test
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/flier/hexdump"
+)
+
+func ExampleOf() {
+	b := []byte("Hello, World!")
+
+	fmt.Printf("frame:\n%+v", hexdump.Of(b))
+	fmt.Printf("%+8o", hexdump.Of(b))
+	// Output:
+	// frame:
+	// 00000000  48 65 6c 6c 6f 2c 20 57  6f 72 6c 64 21           |Hello, World!   |
+	// 00000000  110 145 154 154 157 054 040 127  |Hello, W|
+	// 00000008  157 162 154 144 041              |orld!   |
+}
+
+func TestOf(t *testing.T) {
+	Convey("Given a byte slice", t, func() {
+		b := []byte("Hello")
+
+		for _, tc := range []struct {
+			verb, want string
+		}{
+			{"%+v", "00000000  48 65 6c 6c 6f                                    |Hello           |\n"},
+			{"%+x", "00000000  48 65 6c 6c 6f                                    |Hello           |\n"},
+			{"%+o", "00000000  110 145 154 154 157                                               |Hello           |\n"},
+			{"%+d", "00000000  072 101 108 108 111                                               |Hello           |\n"},
+			{"%+4x", "00000000  48 65 6c 6c  |Hell|\n00000004  6f           |o   |\n"},
+			{"%q", "%!q(hexdump.Formattable=5 bytes)"},
+		} {
+			Convey("When format it with "+tc.verb, func() {
+				s := fmt.Sprintf(tc.verb, hexdump.Of(b))
+
+				Convey("Then it should be dumped in the style of the verb", func() {
+					So(s, ShouldEqual, tc.want)
+				})
+			})
+		}
+
+		Convey("When format it with the options", func() {
+			hex := hexdump.Of(b, hexdump.TwoBytesHex, hexdump.LittleEndian, hexdump.AlwaysColor)
+
+			Convey("Then the style of the options should be used by %v", func() {
+				So(fmt.Sprintf("%+v", hex), ShouldEqual,
+					"00000000     6548    6c6c    006f                                          |Hello           |\n")
+			})
+
+			Convey("Then the style of %o should take precedence over the options", func() {
+				So(fmt.Sprintf("%+o", hex), ShouldEqual, fmt.Sprintf("%+o", hexdump.Of(b)))
+			})
+
+			Convey("Then the + flag should take precedence over the color of the options", func() {
+				So(fmt.Sprintf("%+v", hex), ShouldNotContainSubstring, "\x1b[")
+			})
+		})
+
+		Convey("When the dump fails", func() {
+			s := fmt.Sprintf("%+v", hexdump.Of(b, hexdump.IHex, hexdump.Start(1<<32)))
+
+			Convey("Then the error should be written", func() {
+				So(s, ShouldContainSubstring, "%!v(ERROR=")
+				So(s, ShouldContainSubstring, hexdump.ErrAddress.Error())
+			})
+		})
+
+		Convey("When format it without the + flag", func() {
+			t.Setenv("NO_COLOR", "")
+			t.Setenv("TERM", "xterm")
+
+			s := fmt.Sprintf("%v", hexdump.Of(b))
+
+			Convey("Then it should be colored", func() {
+				So(s, ShouldContainSubstring, hexdump.DefaultTheme.Offset.Sprint("00000000"))
+			})
+		})
+
+		Convey("When format it with NO_COLOR", func() {
+			t.Setenv("NO_COLOR", "1")
+
+			s := fmt.Sprintf("%v", hexdump.Of(b))
+
+			Convey("Then it should not be colored", func() {
+				So(s, ShouldStartWith, "00000000  48 65")
+			})
+		})
+
+		Convey("When convert it to a string", func() {
+			s := hexdump.Of(b, hexdump.Start(0x10)).String()
+
+			Convey("Then it should be the canonical dump with the options", func() {
+				So(s, ShouldEqual, "00000010  48 65 6c 6c 6f                                    |Hello           |\n")
+			})
+		})
+	})
+}
@@ -2,12 +2,13 @@ package hexdump
 
 import (
 	"bufio"
+	"cmp"
 	"encoding/binary"
 	"errors"
-	"fmt"
 	"slices"
 	"strings"
 	"unicode"
+	"unicode/utf8"
 )
 
 type Formatter struct {
@@ -16,42 +17,173 @@ type Formatter struct {
 	DisplayStyle
 	binary.ByteOrder
 	LineWidth int
-}
+	OffsetFormat
+
+	// The display styles of the rows stacked beneath the offset, the default is a single row of the DisplayStyle.
+	Styles []DisplayStyle
+
+	// The format of the cells, the stacked rows are aligned with spaces instead of the separators.
+	Cells CellFormat
 
-const groupsSep = 8
+	// The highlighted ranges of bytes, the later ranges take precedence over the earlier ones.
+	Highlights []HighlightRange
+
+	// How the labels of the highlighted ranges are displayed.
+	Labels LabelMode
+
+	squeezed bool
+}
 
 func (f *Formatter) FormatLine(off int64, skip int, buf []byte) (err error) {
+	f.squeezed = false
+
+	if len(f.Styles) > 1 {
+		return f.formatRows(off, skip, buf)
+	}
+
 	return errors.Join(
 		f.formatOffset(off),
-		f.formatContent(skip, buf),
-		f.formatChars(skip, buf),
+		f.formatContent(f.style(), off, skip, buf),
+		f.formatChars(off, skip, buf),
 		f.Flush())
 }
 
-func (f *Formatter) formatOffset(off int64) (err error) {
-	offset := f.Offset.Sprint(fmt.Sprintf("%08x", off))
+func (f *Formatter) style() DisplayStyle {
+	if len(f.Styles) > 0 {
+		return f.Styles[0]
+	}
+
+	return f.DisplayStyle
+}
 
-	_, err = f.WriteString(offset + " ")
+// formatRows writes a row of each style, the cells of the same bytes are aligned across the rows,
+// and the char column is only written on the first row.
+func (f *Formatter) formatRows(off int64, skip int, buf []byte) (err error) {
+	slot, gap := rowLayout(f.Styles, f.Cells)
 
-	return
+	for i, s := range f.Styles {
+		if i == 0 {
+			err = errors.Join(err, f.formatOffset(off))
+		} else if offset := f.OffsetFormat.Format(off); offset != "" {
+			_, e := f.WriteString(spaces(len(offset) + 1))
+			err = errors.Join(err, e)
+		}
+
+		err = errors.Join(err, f.formatRow(s, slot, gap, off, skip, buf, i == 0))
+
+		if i == 0 {
+			err = errors.Join(err, f.formatChars(off, skip, buf))
+		} else {
+			err = errors.Join(err, f.WriteByte('\n'))
+		}
+	}
+
+	return errors.Join(err, f.Flush())
 }
 
-func (f *Formatter) formatContent(skip int, buf []byte) (err error) {
+// rowLayout returns the number of columns of each byte in the stacked rows,
+// and the number of bytes between the gaps of the rows.
+func rowLayout(styles []DisplayStyle, cells CellFormat) (slot, gap int) {
+	gap = cells.groupBytes(styles[0])
+
+	for _, s := range styles {
+		size := s.GroupSize()
+		slot = max(slot, (s.CellWidth()+size)/size)
+		gap = min(gap, cells.groupBytes(s))
+	}
+
+	return slot, gap
+}
+
+// formatRow writes the cells of a style, each cell is right-aligned to the columns of its bytes,
+// the row is padded to the width of the line if the char column follows it.
+func (f *Formatter) formatRow(s DisplayStyle, slot, gap int, off int64, skip int, buf []byte, pad bool) (err error) {
 	f.Content.SetWriter(f.Writer)
 	defer f.Content.UnsetWriter(f.Writer)
 
-	for i, s := range f.formatLine(f.LineWidth, skip, buf, f.ByteOrder) {
-		if err = f.WriteByte(' '); err != nil {
+	// columns returns the number of columns of the first n bytes of the line.
+	columns := func(n int) int {
+		return n*slot + max(n-1, 0)/gap
+	}
+
+	col, start := 0, skip
+
+	for cell := range formatCells(s, skip, buf, f.ByteOrder) {
+		text := f.Cells.text(s, cell.text)
+		pad := spaces(columns(cell.end) - col - utf8.RuneCountInString(text))
+		col += len(pad) + utf8.RuneCountInString(text)
+
+		if err = f.writeCell(pad, text, off+int64(start), buf[start-skip:min(cell.end-skip, len(buf))]); err != nil {
 			return
 		}
 
-		if i > 0 && f.LineWidth > groupsSep && i%groupsSep == 0 {
-			if err = f.WriteByte(' '); err != nil {
-				return
-			}
+		start = cell.end
+	}
+
+	if pad {
+		_, err = f.WriteString(spaces(columns(f.LineWidth) - col))
+	}
+
+	return
+}
+
+// FormatSqueeze writes a line containing a single '*' in place of identical consecutive lines.
+func (f *Formatter) FormatSqueeze() (err error) {
+	f.squeezed = true
+
+	return errors.Join(
+		f.WriteByte('*'),
+		f.WriteByte('\n'),
+		f.Flush())
+}
+
+// FormatEnd writes a line containing the offset of the end of the input, if the last lines were squeezed,
+// and the legend of the highlighted ranges if the labels are displayed in the legend.
+func (f *Formatter) FormatEnd(off int64) (err error) {
+	if f.squeezed {
+		f.squeezed = false
+
+		if offset := f.OffsetFormat.Format(off); offset != "" {
+			_, err = f.WriteString(f.Offset.Sprint(offset) + "\n")
+		}
+	}
+
+	if f.Labels == LabelLegend {
+		err = errors.Join(err, f.formatLegend())
+	}
+
+	return errors.Join(err, f.Flush())
+}
+
+func (f *Formatter) formatOffset(off int64) (err error) {
+	if offset := f.OffsetFormat.Format(off); offset != "" {
+		_, err = f.WriteString(f.Offset.Sprint(offset) + " ")
+	}
+
+	return
+}
+
+func (f *Formatter) formatContent(style DisplayStyle, off int64, skip int, buf []byte) (err error) {
+	f.Content.SetWriter(f.Writer)
+	defer f.Content.UnsetWriter(f.Writer)
+
+	size := style.GroupSize()
+	first := skip / size // The index of the first cell of the bytes.
+
+	for i, s := range formatLine(style, f.LineWidth, skip, buf, f.ByteOrder) {
+		sep := " "
+		if i > 0 {
+			sep = f.Cells.separator(style, i*size)
+		}
+
+		var b []byte
+
+		n := (i - first) * size
+		if i >= first && n < len(buf) {
+			b = buf[n:min(n+size, len(buf))]
 		}
 
-		if _, err = f.WriteString(s); err != nil {
+		if err = f.writeCell(sep, f.Cells.text(style, s), off+int64(skip+n), b); err != nil {
 			return
 		}
 	}
@@ -59,29 +191,89 @@ func (f *Formatter) formatContent(skip int, buf []byte) (err error) {
 	return
 }
 
-func (f *Formatter) formatChars(skip int, buf []byte) (err error) {
-	chars := f.Chars.Sprint(f.charTable(skip, buf))
+// writeCell writes the text of a cell of the bytes at the offset after the separator,
+// the text is written in the color of the highlighted range or the class of its bytes if any,
+// otherwise in the color of the content column.
+func (f *Formatter) writeCell(sep, text string, at int64, b []byte) (err error) {
+	c := cmp.Or(f.highlight(at, len(b)), f.classColor(b))
+	if c == nil {
+		_, err = f.WriteString(sep + text)
+
+		return
+	}
+
+	if _, err = f.WriteString(sep + c.Sprint(text)); err != nil {
+		return
+	}
 
-	_, err = f.WriteString("  |" + chars + "|\n")
+	f.Content.SetWriter(f.Writer)
 
 	return
 }
 
+func (f *Formatter) formatChars(off int64, skip int, buf []byte) (err error) {
+	chars := f.charColumn(off, skip, buf)
+	left, right := f.Cells.delims()
+	labels := f.lineLabels(off+int64(skip), len(buf))
+
+	_, err = f.WriteString("  " + left + chars + right + labels + "\n")
+
+	return
+}
+
+// charColumn returns the colored text of the char column,
+// the runs of chars in the same highlighted range or byte class are written in the color of the range or class.
+func (f *Formatter) charColumn(off int64, skip int, buf []byte) string {
+	table := f.charTable(skip, buf)
+
+	if !f.hasClasses() && len(f.Highlights) == 0 {
+		return f.Chars.Sprint(table)
+	}
+
+	var b strings.Builder
+
+	start, last := 0, f.Chars
+
+	for i := range len(table) {
+		c := f.Chars
+		if i >= skip && i-skip < len(buf) {
+			c = cmp.Or(f.highlight(off+int64(i), 1), f.byteColor(buf[i-skip]), f.Chars)
+		}
+
+		if c != last {
+			if i > start {
+				b.WriteString(last.Sprint(table[start:i]))
+			}
+
+			start, last = i, c
+		}
+	}
+
+	b.WriteString(last.Sprint(table[start:]))
+
+	return b.String()
+}
+
 func (f *Formatter) charTable(skip int, buf []byte) string {
 	return spaces(skip) +
 		string(slices.Collect(func(yield func(byte) bool) {
 			for _, c := range buf {
-				if c >= 0x80 || !unicode.IsPrint(rune(c)) {
-					c = '.'
-				}
-
-				if !yield(c) {
+				if !yield(printable(c)) {
 					return
 				}
 			}
 		})) + spaces(f.LineWidth-skip-len(buf))
 }
 
+// printable returns the character displayed for a byte in the char column.
+func printable(c byte) byte {
+	if c >= 0x80 || !unicode.IsPrint(rune(c)) {
+		return '.'
+	}
+
+	return c
+}
+
 func spaces(n int) string {
 	return strings.Repeat(" ", max(n, 0))
 }
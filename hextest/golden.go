@@ -0,0 +1,98 @@
+package hextest
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/flier/hexdump"
+)
+
+// GoldenDir is the directory of the golden files, relative to the directory of the package under test.
+const GoldenDir = "testdata"
+
+// GoldenExt is the file extension of the golden files.
+const GoldenExt = ".hexdump"
+
+// UpdateEnv is the environment variable which rewrites the golden files if it is set to a true value like "1".
+const UpdateEnv = "HEXTEST_UPDATE"
+
+// Golden compares the produced bytes with the golden file "testdata/<name>.hexdump",
+// which is the canonical dump of the expected bytes parsed back with [hexdump.Parse],
+// and reports the mismatch with a side-by-side dump like [AssertEqual].
+//
+// The golden file is rewritten with the dump of the produced bytes if the [UpdateEnv] environment variable is set,
+// or the test binary defines an -update flag which is set, so the changes of the golden files are reviewable as text.
+func Golden(t testing.TB, name string, got []byte) {
+	t.Helper()
+
+	path := filepath.Join(GoldenDir, name+GoldenExt)
+
+	if updating() {
+		if err := writeGolden(path, got); err != nil {
+			t.Fatalf("update golden file %s, %v", path, err)
+		}
+
+		t.Logf("updated golden file %s", path)
+
+		return
+	}
+
+	want, err := readGolden(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("golden file %s not found, run the test with "+UpdateEnv+"=1 to create it", path)
+	} else if err != nil {
+		t.Fatalf("read golden file %s, %v", path, err)
+	}
+
+	if msg, ok := compare(want, got); !ok {
+		t.Errorf("golden file %s mismatch, run the test with "+UpdateEnv+"=1 to accept the changes\n%s", path, msg)
+	}
+}
+
+// updating reports whether the golden files are rewritten,
+// the flag is only looked up so it doesn't clash with the -update flag of the package under test.
+func updating() bool {
+	if v, _ := strconv.ParseBool(os.Getenv(UpdateEnv)); v {
+		return true
+	}
+
+	if f := flag.Lookup("update"); f != nil {
+		v, _ := strconv.ParseBool(f.Value.String())
+
+		return v
+	}
+
+	return false
+}
+
+func writeGolden(path string, b []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+
+	if err := hexdump.Bytes(b, hexdump.Output(&buf), hexdump.NeverColor); err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, buf.Bytes(), 0o644) //nolint:gosec
+}
+
+func readGolden(path string) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+
+	return io.ReadAll(hexdump.Parse(f))
+}
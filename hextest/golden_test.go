@@ -0,0 +1,100 @@
+package hextest_test
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/flier/hexdump/hextest"
+)
+
+// update is the -update flag of the package under test, which is shared with [hextest.Golden].
+var update = flag.Bool("update", false, "rewrite the golden files")
+
+func packet() []byte {
+	return append(append([]byte("\x01\x02\x00\x40HEADER\x00\x00"), make([]byte, 48)...), "\xde\xad\xbe\xef"...)
+}
+
+func TestGolden(t *testing.T) {
+	hextest.Golden(t, "packet", packet())
+
+	if *update || os.Getenv(hextest.UpdateEnv) != "" {
+		t.Skip("updating the golden files")
+	}
+
+	Convey("Given a packet different from the golden file", t, func() {
+		got := packet()
+		got[4] = 'h'
+
+		r := &recorder{TB: t}
+
+		Convey("When compare it with the golden file", func() {
+			t.Setenv("NO_COLOR", "1")
+
+			hextest.Golden(r, "packet", got)
+
+			Convey("Then the mismatch should be reported with a hex diff", func() {
+				So(r.errors, ShouldHaveLength, 1)
+				So(r.errors[0], ShouldStartWith, "golden file "+filepath.Join("testdata", "packet.hexdump")+" mismatch")
+				So(r.errors[0], ShouldContainSubstring, "bytes differ at offset 0x4")
+				So(r.errors[0], ShouldContainSubstring, "|...@HEAD|  |  00000000  01 02 00 40 68 45 41 44  |...@hEAD|")
+			})
+		})
+
+		Convey("When compare it with a missing golden file", func() {
+			hextest.Golden(r, "missing", got)
+
+			Convey("Then it should fail with a hint to create it", func() {
+				So(r.errors, ShouldNotBeEmpty)
+				So(r.errors[0], ShouldEndWith, "not found, run the test with HEXTEST_UPDATE=1 to create it")
+			})
+		})
+	})
+}
+
+func TestGoldenUpdate(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		set  func()
+	}{
+		{"the " + hextest.UpdateEnv + " environment variable", func() { t.Setenv(hextest.UpdateEnv, "1") }},
+		{"the -update flag", func() { *update = true }},
+	} {
+		Convey("Given "+tc.name, t, func() {
+			t.Chdir(t.TempDir())
+
+			tc.set()
+
+			Reset(func() { *update = false })
+
+			Convey("When compare a packet with the golden file", func() {
+				hextest.Golden(t, "packet", packet())
+
+				*update = false
+				t.Setenv(hextest.UpdateEnv, "")
+
+				Convey("Then the golden file should be the canonical dump of the packet", func() {
+					b, err := os.ReadFile(filepath.Join("testdata", "packet.hexdump"))
+
+					So(err, ShouldBeNil)
+					So(string(b), ShouldEqual, `00000000  01 02 00 40 48 45 41 44  45 52 00 00 00 00 00 00  |...@HEADER......|
+00000010  00 00 00 00 00 00 00 00  00 00 00 00 00 00 00 00  |................|
+*
+00000030  00 00 00 00 00 00 00 00  00 00 00 00 de ad be ef  |................|
+`)
+
+					Convey("And the next run should pass", func() {
+						r := &recorder{TB: t}
+
+						hextest.Golden(r, "packet", packet())
+
+						So(r.errors, ShouldBeEmpty)
+					})
+				})
+			})
+		})
+	}
+}
@@ -0,0 +1,69 @@
+// Package hextest compares byte slices in tests and reports the mismatches with hex dumps.
+package hextest
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/flier/hexdump"
+)
+
+const (
+	// LineWidth is the number of bytes per line of each side of the dump.
+	LineWidth = hexdump.DefaultDiffWidth
+
+	// ContextLines is the number of lines around the line of the first differing byte in the dump.
+	ContextLines = 2
+)
+
+// AssertEqual reports an error with the first differing offset
+// and a side-by-side dump of the lines around it if the bytes are not equal.
+//
+// The differing bytes are colored unless the NO_COLOR environment variable is set or the terminal is dumb,
+// like the auto color mode of [hexdump.Dumper], except that the output of tests is never a terminal.
+func AssertEqual(t testing.TB, want, got []byte) {
+	t.Helper()
+
+	if msg, ok := compare(want, got); !ok {
+		t.Errorf("%s", msg)
+	}
+}
+
+// compare returns the first differing offset and a side-by-side dump of the lines around it
+// if the bytes are not equal.
+func compare(want, got []byte) (string, bool) {
+	if bytes.Equal(want, got) {
+		return "", true
+	}
+
+	off := int64(firstDiff(want, got))
+	start := max(off/LineWidth-ContextLines, 0) * LineWidth
+	end := (off/LineWidth + ContextLines + 1) * LineWidth
+
+	var b strings.Builder
+
+	_, _ = hexdump.Diff(bytes.NewReader(want), bytes.NewReader(got),
+		hexdump.Output(&b), hexdump.Color(colorMode()), hexdump.Range(start, end), hexdump.Verbose(true))
+
+	return fmt.Sprintf("bytes differ at offset %#x, want %d bytes, got %d bytes:\n%s", off, len(want), len(got), b.String()), false
+}
+
+// firstDiff returns the offset of the first differing byte, or the length of the shorter bytes.
+func firstDiff(want, got []byte) (n int) {
+	for n < len(want) && n < len(got) && want[n] == got[n] {
+		n++
+	}
+
+	return
+}
+
+func colorMode() hexdump.ColorMode {
+	if os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb" {
+		return hexdump.ColorNever
+	}
+
+	return hexdump.ColorAlways
+}
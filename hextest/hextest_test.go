@@ -0,0 +1,76 @@
+package hextest_test
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/flier/hexdump"
+	"github.com/flier/hexdump/hextest"
+)
+
+// recorder records the errors reported by the helpers.
+type recorder struct {
+	testing.TB
+
+	errors []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func (r *recorder) Fatalf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func (r *recorder) Logf(string, ...any) {}
+
+func TestAssertEqual(t *testing.T) {
+	Convey("Given some packets", t, func() {
+		want := []byte("\x01\x02\x00\x2cThe quick brown fox jumps over the lazy dog\xde\xad\xbe\xef")
+		got := []byte("\x01\x02\x00\x2cThe quick brown fox jumps over the lazy cat\xde\xad\xbe\xef")
+
+		r := &recorder{TB: t}
+
+		Convey("When assert the same packets", func() {
+			hextest.AssertEqual(r, want, want)
+
+			Convey("Then no error should be reported", func() {
+				So(r.errors, ShouldBeEmpty)
+			})
+		})
+
+		Convey("When assert the different packets without color", func() {
+			t.Setenv("NO_COLOR", "1")
+
+			hextest.AssertEqual(r, want, got)
+
+			Convey("Then the first differing offset and the lines around it should be reported", func() {
+				So(r.errors, ShouldResemble, []string{`bytes differ at offset 0x2c, want 51 bytes, got 51 bytes:
+00000018  6a 75 6d 70 73 20 6f 76  |jumps ov|     00000018  6a 75 6d 70 73 20 6f 76  |jumps ov|
+00000020  65 72 20 74 68 65 20 6c  |er the l|     00000020  65 72 20 74 68 65 20 6c  |er the l|
+00000028  61 7a 79 20 64 6f 67 de  |azy dog.|  |  00000028  61 7a 79 20 63 61 74 de  |azy cat.|
+00000030  ad be ef                 |...     |     00000030  ad be ef                 |...     |
+0000002c-0000002f  0000002c-0000002f  changed
+`})
+			})
+		})
+
+		Convey("When assert the packets of different lengths with color", func() {
+			t.Setenv("NO_COLOR", "")
+			t.Setenv("TERM", "xterm")
+
+			hextest.AssertEqual(r, want, got[:10])
+
+			Convey("Then the differing bytes should be colored", func() {
+				So(r.errors, ShouldHaveLength, 1)
+				So(r.errors[0], ShouldStartWith, "bytes differ at offset 0xa, want 51 bytes, got 10 bytes:\n")
+				So(r.errors[0], ShouldContainSubstring, hexdump.DefaultTheme.Old.Sprint("69"))
+			})
+		})
+	})
+}
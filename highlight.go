@@ -0,0 +1,116 @@
+package hexdump
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+//go:generate go tool stringer -type=LabelMode -linecomment
+
+// LabelMode is how the labels of the highlighted ranges are displayed.
+type LabelMode int //nolint:recvcheck
+
+const (
+	LabelNone   LabelMode = iota // none
+	LabelLegend                  // legend
+	LabelInline                  // inline
+)
+
+func (m LabelMode) MarshalText() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+func (m *LabelMode) UnmarshalText(text []byte) error {
+	for i := range len(_LabelMode_index) - 1 {
+		if strings.EqualFold(string(text), _LabelMode_name[_LabelMode_index[i]:_LabelMode_index[i+1]]) {
+			*m = LabelMode(i)
+
+			return nil
+		}
+	}
+
+	return fmt.Errorf("label mode %q, %w", text, os.ErrInvalid)
+}
+
+// HighlightRange is a highlighted range of bytes between the offsets [Start, End).
+type HighlightRange struct {
+	Start int64        // The offset of the first byte.
+	End   int64        // The offset after the last byte.
+	Color *color.Color // The color of the bytes in the content and char columns.
+	Label string       // The label of the range.
+}
+
+// DefaultHighlight is the color of the highlighted ranges without color.
+var DefaultHighlight = color.New(color.ReverseVideo)
+
+// highlight returns the color of the last range containing the n bytes at the offset, or nil if none.
+func (f *Formatter) highlight(off int64, n int) *color.Color {
+	if n == 0 {
+		return nil
+	}
+
+	for i := len(f.Highlights) - 1; i >= 0; i-- {
+		if r := f.Highlights[i]; r.Start <= off && off+int64(n) <= r.End {
+			return r.Color
+		}
+	}
+
+	return nil
+}
+
+// highlighted reports whether any highlighted range overlaps the n bytes at the offset.
+func (d *Dumper) highlighted(off int64, n int) bool {
+	for _, r := range d.Highlights {
+		if r.Start < off+int64(n) && off < r.End {
+			return true
+		}
+	}
+
+	return false
+}
+
+// lineLabels returns the labels of the ranges starting in the bytes of a line.
+func (f *Formatter) lineLabels(off int64, n int) string {
+	if f.Labels != LabelInline {
+		return ""
+	}
+
+	var labels []string
+
+	for _, r := range f.Highlights {
+		if r.Label != "" && off <= r.Start && r.Start < off+int64(n) {
+			labels = append(labels, r.Color.Sprint(r.Label))
+		}
+	}
+
+	if len(labels) == 0 {
+		return ""
+	}
+
+	return "  " + strings.Join(labels, ", ")
+}
+
+// formatLegend writes a line of the offsets and label of each labeled range.
+func (f *Formatter) formatLegend() (err error) {
+	offset := f.OffsetFormat
+	if offset.Radix == RadixNone {
+		offset.Radix = RadixHex
+	}
+
+	for _, r := range f.Highlights {
+		if r.Label == "" {
+			continue
+		}
+
+		text := f.Offset.Sprint(offset.Format(r.Start)+"-"+offset.Format(r.End)) + "  " + r.Color.Sprint(r.Label) + "\n"
+
+		if _, err = f.WriteString(text); err != nil {
+			return
+		}
+	}
+
+	return
+}
@@ -0,0 +1,82 @@
+package hexdump_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/fatih/color"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/flier/hexdump"
+)
+
+func ExampleHighlight() {
+	frame := []byte("\x01\x02\x00\x00\x00\x0cHello, World!\xde\xad\xbe\xef")
+
+	_ = hexdump.Bytes(frame,
+		hexdump.Highlight(2, 6, color.New(color.FgYellow), "length"),
+		hexdump.Highlight(6, 18, color.New(color.FgGreen), "payload"),
+		hexdump.Highlight(18, 22, color.New(color.FgRed), "crc"),
+		hexdump.Legend)
+	// Output:
+	// 00000000  01 02 00 00 00 0c 48 65  6c 6c 6f 2c 20 57 6f 72  |......Hello, Wor|
+	// 00000010  6c 64 21 de ad be ef                              |ld!....         |
+	// 00000002-00000006  length
+	// 00000006-00000012  payload
+	// 00000012-00000016  crc
+}
+
+func ExampleInlineLabels() {
+	frame := []byte("\x01\x02\x00\x00\x00\x0cHello, World!\xde\xad\xbe\xef")
+
+	_ = hexdump.Bytes(frame,
+		hexdump.Highlight(2, 6, nil, "length"),
+		hexdump.Highlight(6, 18, nil, "payload"),
+		hexdump.Highlight(18, 22, nil, "crc"),
+		hexdump.InlineLabels)
+	// Output:
+	// 00000000  01 02 00 00 00 0c 48 65  6c 6c 6f 2c 20 57 6f 72  |......Hello, Wor|  length, payload
+	// 00000010  6c 64 21 de ad be ef                              |ld!....         |  crc
+}
+
+func TestHighlight(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given a range across the line boundary", t, func() {
+		var b strings.Builder
+
+		hi := color.New(color.FgRed)
+
+		_ = hexdump.String("0123456789", hexdump.AlwaysColor, hexdump.LineWidth(4), hexdump.Start(2),
+			hexdump.Highlight(3, 6, hi, "x"), hexdump.Output(&b))
+
+		Convey("Then the bytes in the range should be highlighted on both lines", func() {
+			lines := strings.Split(b.String(), "\n")
+
+			So(lines[0], ShouldNotContainSubstring, hi.Sprint("30"))
+			So(lines[0], ShouldContainSubstring, " "+hi.Sprint("31"))
+			So(lines[0], ShouldContainSubstring, hi.Sprint("1"))
+			So(lines[1], ShouldContainSubstring, " "+hi.Sprint("32"))
+			So(lines[1], ShouldContainSubstring, " "+hi.Sprint("33"))
+			So(lines[1], ShouldContainSubstring, hi.Sprint("23"))
+			So(lines[1], ShouldNotContainSubstring, hi.Sprint("34"))
+		})
+	})
+
+	Convey("Given a range in the identical lines", t, func() {
+		var b strings.Builder
+
+		_ = hexdump.Bytes(make([]byte, 64), hexdump.NeverColor, hexdump.InlineLabels,
+			hexdump.Highlight(0x20, 0x24, color.New(color.FgRed), "nonce"), hexdump.Output(&b))
+
+		Convey("Then the highlighted line should not be squeezed", func() {
+			zeros := "00 00 00 00 00 00 00 00  00 00 00 00 00 00 00 00  |................|"
+
+			So(b.String(), ShouldEqual, "00000000  "+zeros+"\n"+
+				"*\n"+
+				"00000020  "+zeros+"  nonce\n"+
+				"*\n"+
+				"00000040\n")
+		})
+	})
+}
@@ -0,0 +1,165 @@
+package hexdump
+
+import (
+	"bufio"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// The record types of Intel HEX.
+const (
+	ihexData                   = 0x00
+	ihexEndOfFile              = 0x01
+	ihexExtendedSegmentAddress = 0x02
+	ihexStartSegmentAddress    = 0x03
+	ihexExtendedLinearAddress  = 0x04
+	ihexStartLinearAddress     = 0x05
+)
+
+const (
+	ihexSegmentSize = 1 << 16
+	ihexMaxAddress  = 1 << 32
+	ihexMaxData     = 0xff
+)
+
+// ihexEmitter writes the binary content as Intel HEX records.
+type ihexEmitter struct {
+	w     *bufio.Writer
+	upper int64 // The upper 16 bits of the address of the last extended linear address record.
+}
+
+func (e *ihexEmitter) FormatLine(off int64, _ int, buf []byte) error {
+	if off < 0 || off+int64(len(buf)) > ihexMaxAddress {
+		return fmt.Errorf("address %#x, %w", off, ErrAddress)
+	}
+
+	for len(buf) > 0 {
+		if upper := off / ihexSegmentSize; upper != e.upper {
+			e.record(0, ihexExtendedLinearAddress, []byte{byte(upper >> 8), byte(upper)})
+			e.upper = upper
+		}
+
+		n := min(len(buf), ihexMaxData, int(ihexSegmentSize-off%ihexSegmentSize))
+
+		e.record(uint16(off), ihexData, buf[:n]) //nolint:gosec
+
+		off += int64(n)
+		buf = buf[n:]
+	}
+
+	return e.w.Flush()
+}
+
+func (e *ihexEmitter) FormatEnd(int64) error {
+	e.record(0, ihexEndOfFile, nil)
+
+	return e.w.Flush()
+}
+
+func (e *ihexEmitter) record(addr uint16, typ byte, data []byte) {
+	b := append([]byte{byte(len(data)), byte(addr >> 8), byte(addr), typ}, data...)
+	b = append(b, -sum(b))
+
+	_, _ = e.w.WriteString(":" + strings.ToUpper(hex.EncodeToString(b)) + "\n")
+}
+
+func sum(b []byte) (s byte) {
+	for _, c := range b {
+		s += c
+	}
+
+	return
+}
+
+// DecodeIHex decodes the Intel HEX records read from r into a sparse memory image.
+func DecodeIHex(r io.Reader) (*Image, error) {
+	var (
+		m    Image
+		base int64
+	)
+
+	s := bufio.NewScanner(r)
+
+	for n := 1; s.Scan(); n++ {
+		line := strings.TrimSpace(s.Text())
+		if line == "" {
+			continue
+		}
+
+		rec, err := decodeRecord(line, ":")
+		if err != nil {
+			return nil, fmt.Errorf("line %d, %w", n, err)
+		}
+
+		if len(rec) < 5 || int(rec[0]) != len(rec)-5 {
+			return nil, fmt.Errorf("line %d record length, %w", n, ErrSyntax)
+		}
+
+		if sum(rec) != 0 {
+			return nil, fmt.Errorf("line %d, %w", n, ErrChecksum)
+		}
+
+		addr, typ, data := int64(rec[1])<<8|int64(rec[2]), rec[3], rec[4:len(rec)-1]
+
+		switch typ {
+		case ihexData:
+			m.add(base+addr, data)
+
+		case ihexEndOfFile:
+			m.normalize()
+
+			return &m, nil
+
+		case ihexExtendedSegmentAddress, ihexExtendedLinearAddress:
+			if len(data) != 2 {
+				return nil, fmt.Errorf("line %d extended address, %w", n, ErrSyntax)
+			}
+
+			base = int64(data[0])<<8 | int64(data[1])
+
+			if typ == ihexExtendedSegmentAddress {
+				base <<= 4
+			} else {
+				base <<= 16
+			}
+
+		case ihexStartSegmentAddress, ihexStartLinearAddress:
+			if len(data) != 4 {
+				return nil, fmt.Errorf("line %d start address, %w", n, ErrSyntax)
+			}
+
+			if typ == ihexStartSegmentAddress {
+				m.Entry = (int64(data[0])<<8|int64(data[1]))<<4 + (int64(data[2])<<8 | int64(data[3]))
+			} else {
+				m.Entry = int64(data[0])<<24 | int64(data[1])<<16 | int64(data[2])<<8 | int64(data[3])
+			}
+
+		default:
+			return nil, fmt.Errorf("line %d record type %02x, %w", n, typ, ErrSyntax)
+		}
+	}
+
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+
+	return nil, fmt.Errorf("end of file record, %w", io.ErrUnexpectedEOF)
+}
+
+// decodeRecord decodes the hex digits of a record after the mark.
+func decodeRecord(line, mark string) ([]byte, error) {
+	digits, ok := strings.CutPrefix(line, mark)
+	if !ok {
+		return nil, fmt.Errorf("record mark %q, %w", line, ErrSyntax)
+	}
+
+	rec, err := hex.DecodeString(digits)
+	if err != nil {
+		return nil, errors.Join(ErrSyntax, err)
+	}
+
+	return rec, nil
+}
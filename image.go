@@ -0,0 +1,86 @@
+package hexdump
+
+import (
+	"cmp"
+	"errors"
+	"slices"
+)
+
+// ErrChecksum indicates that the checksum of a record doesn't match its content.
+var ErrChecksum = errors.New("checksum mismatch")
+
+// ErrAddress indicates that an address can't be represented in the record format.
+var ErrAddress = errors.New("address out of range")
+
+// Image is a sparse memory image of binary content, decoded from the Intel HEX or Motorola S-record files.
+type Image struct {
+	Segments []Segment // The segments of content sorted by address.
+	Entry    int64     // The start address of the execution.
+	Header   []byte    // The content of the header record.
+}
+
+// Segment is a contiguous content at an address of the [Image].
+type Segment struct {
+	Addr int64  // The address of the content.
+	Data []byte // The content of the segment.
+}
+
+// End returns the address of the end of the segment.
+func (s *Segment) End() int64 { return s.Addr + int64(len(s.Data)) }
+
+// Size returns the number of bytes in the image.
+func (m *Image) Size() (n int64) {
+	for _, s := range m.Segments {
+		n += int64(len(s.Data))
+	}
+
+	return
+}
+
+// Dump converts each segment of the image into a readable ASCII table using the provided options,
+// the offsets of lines are the addresses of the content.
+func (m *Image) Dump(x ...Option) (err error) {
+	for _, s := range m.Segments {
+		if err = Bytes(s.Data, append(slices.Clip(x), Start(s.Addr))...); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// add appends the content at the address to the image.
+func (m *Image) add(addr int64, data []byte) {
+	if n := len(m.Segments); n > 0 && m.Segments[n-1].End() == addr {
+		m.Segments[n-1].Data = append(m.Segments[n-1].Data, data...)
+	} else {
+		m.Segments = append(m.Segments, Segment{addr, slices.Clone(data)})
+	}
+}
+
+// normalize sorts the segments by address and merges the contiguous or overlapping segments.
+func (m *Image) normalize() {
+	slices.SortStableFunc(m.Segments, func(a, b Segment) int { return cmp.Compare(a.Addr, b.Addr) })
+
+	var segments []Segment
+
+	for _, s := range m.Segments {
+		n := len(segments)
+
+		if n == 0 || segments[n-1].End() < s.Addr {
+			segments = append(segments, s)
+
+			continue
+		}
+
+		last := &segments[n-1]
+
+		if end := s.End(); end > last.End() {
+			last.Data = append(last.Data, make([]byte, end-last.End())...)
+		}
+
+		copy(last.Data[s.Addr-last.Addr:], s.Data)
+	}
+
+	m.Segments = segments
+}
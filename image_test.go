@@ -0,0 +1,141 @@
+package hexdump_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/flier/hexdump"
+)
+
+func ExampleIHex() {
+	_ = hexdump.String("Hello, World!\n", hexdump.IHex, hexdump.Start(0xfff8))
+	// Output:
+	// :08FFF80048656C6C6F2C20576A
+	// :020000040001F9
+	// :060000006F726C64210A1E
+	// :00000001FF
+}
+
+func ExampleS19() {
+	_ = hexdump.String("Hello, World!\n", hexdump.S19, hexdump.Start(0x1000), hexdump.Name("hello"))
+	// Output:
+	// S008000068656C6C6FE3
+	// S111100048656C6C6F2C20576F726C64210A6B
+	// S5030001FB
+	// S9031000EC
+}
+
+func ExampleDecodeIHex() {
+	m, _ := hexdump.DecodeIHex(strings.NewReader(`:08FFF80048656C6C6F2C20576A
+:020000040001F9
+:060000006F726C64210A1E
+:00000001FF
+`))
+
+	_ = m.Dump()
+	// Output:
+	// 0000fff0                           48 65 6c 6c 6f 2c 20 57  |        Hello, W|
+	// 00010000  6f 72 6c 64 21 0a                                 |orld!.          |
+}
+
+func ExampleDecodeSRec() {
+	m, _ := hexdump.DecodeSRec(strings.NewReader(`S008000068656C6C6FE3
+S111100048656C6C6F2C20576F726C64210A6B
+S5030001FB
+S9031000EC
+`))
+
+	_ = m.Dump()
+	// Output:
+	// 00001000  48 65 6c 6c 6f 2c 20 57  6f 72 6c 64 21 0a        |Hello, World!.  |
+}
+
+func TestRecords(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given some random binary content", t, func() {
+		r := rand.New(rand.NewPCG(1, 2)) //nolint:gosec
+
+		b := make([]byte, 1000)
+		for i := range b {
+			b[i] = byte(r.UintN(256))
+		}
+
+		for i, tc := range []struct {
+			mode   hexdump.Option
+			decode func(r *bytes.Buffer) (*hexdump.Image, error)
+			start  int64
+		}{
+			{hexdump.IHex, decodeIHex, 0},
+			{hexdump.IHex, decodeIHex, 0x1fffe},
+			{hexdump.S19, decodeSRec, 0x8000},
+			{hexdump.S28, decodeSRec, 0x123456},
+			{hexdump.S37, decodeSRec, 0x12345678},
+		} {
+			Convey(fmt.Sprintf("#%d When encode it at %#x", i, tc.start), func() {
+				var buf bytes.Buffer
+
+				So(hexdump.Bytes(b, tc.mode, hexdump.Start(tc.start), hexdump.LineWidth(32), hexdump.Output(&buf)), ShouldBeNil)
+
+				Convey("Then decode it back into the image", func() {
+					m, err := tc.decode(&buf)
+
+					So(err, ShouldBeNil)
+					So(m.Segments, ShouldHaveLength, 1)
+					So(m.Segments[0].Addr, ShouldEqual, tc.start)
+					So(m.Segments[0].Data, ShouldResemble, b)
+					So(m.Size(), ShouldEqual, len(b))
+				})
+			})
+		}
+	})
+
+	Convey("Given the records with a bad checksum", t, func() {
+		_, err := hexdump.DecodeIHex(strings.NewReader(":0100000041BF\n:00000001FF\n"))
+		So(err, ShouldWrap, hexdump.ErrChecksum)
+
+		_, err = hexdump.DecodeSRec(strings.NewReader("S104000041BB\nS9030000FC\n"))
+		So(err, ShouldWrap, hexdump.ErrChecksum)
+	})
+
+	Convey("Given the records out of order and overlapping", t, func() {
+		m, err := hexdump.DecodeSRec(strings.NewReader("S106000444454626\nS106000041424333\nS104000358A0\n"))
+
+		So(err, ShouldBeNil)
+		So(m.Segments, ShouldResemble, []hexdump.Segment{{Addr: 0, Data: []byte("ABCXDEF")}})
+	})
+
+	Convey("Given a name longer than the data of a record", t, func() {
+		var buf bytes.Buffer
+
+		name := strings.Repeat("/very/long/path", 20)
+
+		So(hexdump.String("Hello", hexdump.S19, hexdump.Name(name), hexdump.Output(&buf)), ShouldBeNil)
+
+		Convey("Then the header should be cut to a valid record", func() {
+			header, _, _ := strings.Cut(buf.String(), "\n")
+
+			So(header, ShouldStartWith, "S0FF0000")
+			So(header, ShouldContainSubstring, strings.ToUpper(hex.EncodeToString([]byte(name[:252]))))
+
+			m, err := hexdump.DecodeSRec(&buf)
+
+			So(err, ShouldBeNil)
+			So(m.Segments, ShouldResemble, []hexdump.Segment{{Addr: 0, Data: []byte("Hello")}})
+		})
+	})
+
+	Convey("Given an address beyond the record format", t, func() {
+		So(hexdump.String("Hello", hexdump.S19, hexdump.Start(0xfffe), hexdump.Output(new(bytes.Buffer))), ShouldWrap, hexdump.ErrAddress)
+	})
+}
+
+func decodeIHex(r *bytes.Buffer) (*hexdump.Image, error) { return hexdump.DecodeIHex(r) }
+
+func decodeSRec(r *bytes.Buffer) (*hexdump.Image, error) { return hexdump.DecodeSRec(r) }
@@ -0,0 +1,610 @@
+package hexdump
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// FormatLayout is a list of format strings of the hexdump(1) format language,
+// each format string is applied in turn to every block of the input.
+//
+// A format string contains format units like `iteration/byte_count "format"`,
+// the format is a printf(3) format with the additional conversions:
+//
+//	%_a[dox]  the offset of the next byte displayed, in decimal, octal or hex.
+//	%_A[dox]  the offset of the end of the input, displayed once after all the input.
+//	%_c       the byte as a character, with C escapes or three-digit octal for non-printable bytes.
+//	%_p       the byte as a character, or '.' for non-printable bytes.
+//	%_u       the byte as a US ASCII character, with names for control bytes like 'nul' and 'del'.
+type FormatLayout struct {
+	formats []*formatString
+	end     *formatUnit // The format unit of the offset of the end of the input.
+}
+
+// formatString is a list of format units.
+type formatString struct {
+	units []*formatUnit
+	size  int // The number of bytes interpreted by the format string.
+}
+
+// formatUnit is a format applied iteration times to a byte count of the input.
+type formatUnit struct {
+	reps        int  // The number of iterations.
+	setReps     bool // The iteration count is specified.
+	count       int  // The number of bytes interpreted by each iteration.
+	conversions []*conversion
+	end         bool // The format unit displays the offset of the end of the input.
+}
+
+type conversionKind int
+
+const (
+	convText conversionKind = iota
+	convAddress
+	convChar
+	convInt
+	convUint
+	convFloat
+	convString
+	convEscapedChar
+	convPrintableChar
+	convASCIIChar
+)
+
+// conversion is a conversion of the format with the literal text before it.
+type conversion struct {
+	kind    conversionKind
+	text    string // The literal text before the conversion.
+	spec    string // The flags, width and precision of the conversion.
+	verb    byte   // The conversion character of fmt.
+	size    int    // The number of bytes interpreted by the conversion.
+	hasPrec bool   // The precision is specified.
+}
+
+// ParseLayout parses the format strings of the hexdump(1) format language, like `hexdump -e`.
+func ParseLayout(formats ...string) (*FormatLayout, error) {
+	l := new(FormatLayout)
+
+	for _, s := range formats {
+		fs, err := l.parseFormatString(s)
+		if err != nil {
+			return nil, fmt.Errorf("format %q, %w", s, err)
+		}
+
+		l.formats = append(l.formats, fs)
+	}
+
+	if l.BlockSize() == 0 {
+		return nil, fmt.Errorf("format interprets no data, %w", ErrSyntax)
+	}
+
+	return l, nil
+}
+
+// ReadFormats reads the format strings from a format file, like `hexdump -f`.
+//
+// Each line is a format string, the empty lines and the lines starting with '#' are ignored.
+func ReadFormats(r io.Reader) (formats []string, err error) {
+	s := bufio.NewScanner(r)
+
+	for s.Scan() {
+		line := strings.TrimLeftFunc(s.Text(), unicode.IsSpace)
+
+		if line != "" && line[0] != '#' {
+			formats = append(formats, line)
+		}
+	}
+
+	return formats, s.Err()
+}
+
+// BlockSize returns the number of bytes interpreted by the longest format string.
+func (l *FormatLayout) BlockSize() (n int) {
+	for _, fs := range l.formats {
+		n = max(n, fs.size)
+	}
+
+	return
+}
+
+func (l *FormatLayout) parseFormatString(s string) (*formatString, error) {
+	fs := new(formatString)
+
+	for p := s; ; {
+		if p = strings.TrimLeftFunc(p, unicode.IsSpace); p == "" {
+			break
+		}
+
+		fu := &formatUnit{reps: 1}
+
+		var err error
+
+		if digits := leadingDigits(p); digits != "" {
+			if p = p[len(digits):]; p != "" && p[0] != '/' && !isSpace(p[0]) {
+				return nil, fmt.Errorf("iteration count, %w", ErrSyntax)
+			}
+
+			if fu.reps, err = strconv.Atoi(digits); err != nil {
+				return nil, errors.Join(ErrSyntax, err)
+			}
+
+			fu.setReps = true
+			p = strings.TrimLeftFunc(p, unicode.IsSpace)
+		}
+
+		if p != "" && p[0] == '/' {
+			p = strings.TrimLeftFunc(p[1:], unicode.IsSpace)
+
+			if digits := leadingDigits(p); digits != "" {
+				if p = p[len(digits):]; p != "" && p[0] != '"' && !isSpace(p[0]) {
+					return nil, fmt.Errorf("byte count, %w", ErrSyntax)
+				}
+
+				if fu.count, err = strconv.Atoi(digits); err != nil {
+					return nil, errors.Join(ErrSyntax, err)
+				}
+
+				p = strings.TrimLeftFunc(p, unicode.IsSpace)
+			}
+		}
+
+		if p == "" || p[0] != '"' {
+			return nil, fmt.Errorf("missing format, %w", ErrSyntax)
+		}
+
+		format, rest, ok := strings.Cut(p[1:], `"`)
+		if !ok {
+			return nil, fmt.Errorf("unterminated format, %w", ErrSyntax)
+		}
+
+		p = rest
+
+		if err = l.parseFormatUnit(fu, unescape(format)); err != nil {
+			return nil, err
+		}
+
+		fs.units = append(fs.units, fu)
+		fs.size += fu.reps * fu.count
+	}
+
+	return fs, nil
+}
+
+func (l *FormatLayout) parseFormatUnit(fu *formatUnit, format string) error {
+	var (
+		text  strings.Builder
+		nconv int
+	)
+
+	for p := format; p != ""; {
+		i := strings.IndexByte(p, '%')
+		if i < 0 {
+			text.WriteString(p)
+
+			break
+		}
+
+		text.WriteString(p[:i])
+
+		if strings.HasPrefix(p[i:], "%%") {
+			text.WriteByte('%')
+
+			p = p[i+2:]
+
+			continue
+		}
+
+		c := &conversion{text: text.String()}
+		text.Reset()
+
+		p = p[i+1:]
+		n := len(p) - len(strings.TrimLeft(p, "#-+ 0"))
+		n += len(leadingDigits(p[n:]))
+
+		prec := -1
+
+		if n < len(p) && p[n] == '.' {
+			digits := leadingDigits(p[n+1:])
+			prec, _ = strconv.Atoi(digits)
+			c.hasPrec = true
+			n += 1 + len(digits)
+		}
+
+		if n == len(p) {
+			return fmt.Errorf("missing conversion, %w", ErrSyntax)
+		}
+
+		c.spec, p = p[:n], p[n:]
+
+		if err := l.parseConversion(fu, c, prec, &p); err != nil {
+			return err
+		}
+
+		if c.kind != convAddress && fu.count > 0 {
+			if nconv++; nconv > 1 {
+				return fmt.Errorf("byte count with multiple conversions, %w", ErrSyntax)
+			}
+		}
+
+		fu.conversions = append(fu.conversions, c)
+	}
+
+	if text.Len() > 0 {
+		fu.conversions = append(fu.conversions, &conversion{kind: convText, text: text.String()})
+	}
+
+	if fu.count == 0 {
+		for _, c := range fu.conversions {
+			fu.count += c.size
+		}
+	}
+
+	return nil
+}
+
+// parseConversion parses the conversion character at the beginning of p.
+func (l *FormatLayout) parseConversion(fu *formatUnit, c *conversion, prec int, p *string) (err error) {
+	verb := (*p)[0]
+	*p = (*p)[1:]
+
+	switch verb {
+	case 'c':
+		c.kind, c.verb = convChar, 's'
+		c.size, err = byteCount(fu.count, verb, 1)
+
+	case 'd', 'i':
+		c.kind, c.verb = convInt, 'd'
+		c.size, err = byteCount(fu.count, verb, fourBytes, 1, twoBytes, fourBytes, eightBytes)
+
+	case 'o', 'u', 'x', 'X':
+		c.kind, c.verb = convUint, verb
+		if verb == 'u' {
+			c.verb = 'd'
+		}
+
+		c.size, err = byteCount(fu.count, verb, fourBytes, 1, twoBytes, fourBytes, eightBytes)
+
+	case 'e', 'E', 'f', 'g', 'G':
+		c.kind, c.verb = convFloat, verb
+		c.size, err = byteCount(fu.count, verb, eightBytes, fourBytes, eightBytes)
+
+	case 's':
+		c.kind, c.verb = convString, 's'
+
+		switch {
+		case fu.count > 0:
+			c.size = fu.count
+		case prec >= 0:
+			c.size = prec
+		default:
+			return fmt.Errorf("%%s requires a byte count or precision, %w", ErrSyntax)
+		}
+
+	case '_':
+		return l.parseExtension(fu, c, p)
+
+	default:
+		return fmt.Errorf("bad conversion character %%%c, %w", verb, ErrSyntax)
+	}
+
+	return
+}
+
+// parseExtension parses the conversion character after '_' at the beginning of p.
+func (l *FormatLayout) parseExtension(fu *formatUnit, c *conversion, p *string) (err error) {
+	if *p == "" {
+		return fmt.Errorf("missing conversion, %w", ErrSyntax)
+	}
+
+	verb := (*p)[0]
+	*p = (*p)[1:]
+
+	switch verb {
+	case 'A', 'a':
+		if *p == "" || strings.IndexByte("dox", (*p)[0]) < 0 {
+			return fmt.Errorf("bad conversion character %%_%c, %w", verb, ErrSyntax)
+		}
+
+		c.kind, c.verb = convAddress, (*p)[0]
+		*p = (*p)[1:]
+
+		if verb == 'A' {
+			fu.end = true
+			l.end = fu
+		}
+
+	case 'c':
+		c.kind, c.verb = convEscapedChar, 's'
+		c.size, err = byteCount(fu.count, verb, 1)
+
+	case 'p':
+		c.kind, c.verb = convPrintableChar, 's'
+		c.size, err = byteCount(fu.count, verb, 1)
+
+	case 'u':
+		c.kind, c.verb = convASCIIChar, 's'
+		c.size, err = byteCount(fu.count, verb, 1)
+
+	default:
+		return fmt.Errorf("bad conversion character %%_%c, %w", verb, ErrSyntax)
+	}
+
+	return
+}
+
+// byteCount returns the byte count of a conversion, which is the default size if the count isn't specified.
+func byteCount(count int, verb byte, size int, sizes ...int) (int, error) {
+	switch {
+	case count == 0:
+		return size, nil
+	case count == size && len(sizes) == 0:
+		return size, nil
+	}
+
+	for _, n := range sizes {
+		if n == count {
+			return n, nil
+		}
+	}
+
+	return 0, fmt.Errorf("bad byte count %d for %%%c, %w", count, verb, ErrSyntax)
+}
+
+func leadingDigits(s string) string {
+	return s[:len(s)-len(strings.TrimLeft(s, "0123456789"))]
+}
+
+func isSpace(c byte) bool { return unicode.IsSpace(rune(c)) }
+
+// unescape replaces the backslash escapes of the format.
+func unescape(s string) string {
+	var b strings.Builder
+
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i+1 == len(s) {
+			b.WriteByte(s[i])
+
+			continue
+		}
+
+		i++
+
+		if c := strings.IndexByte(`abfnrtv`, s[i]); c >= 0 {
+			b.WriteByte("\a\b\f\n\r\t\v"[c])
+		} else {
+			b.WriteByte(s[i])
+		}
+	}
+
+	return b.String()
+}
+
+// layoutFormatter writes the blocks of binary content with the format strings of a [FormatLayout].
+type layoutFormatter struct {
+	*bufio.Writer
+	*FormatLayout
+	binary.ByteOrder
+
+	size int // The block size.
+}
+
+func newLayoutFormatter(w *bufio.Writer, l *FormatLayout, order binary.ByteOrder) *layoutFormatter {
+	return &layoutFormatter{w, l, order, l.BlockSize()}
+}
+
+// FormatLine writes a block of binary content at the offset with each format string.
+//
+// A partial block is zero-padded to display all available data,
+// and the conversions beyond the end of the input are replaced with spaces.
+func (f *layoutFormatter) FormatLine(off int64, _ int, buf []byte) error {
+	end := int64(-1)
+	if len(buf) < f.size {
+		end = off + int64(len(buf))
+	}
+
+	block := make([]byte, f.size)
+	copy(block, buf)
+
+	for _, fs := range f.formats {
+		addr, b := off, block
+
+		for i, fu := range fs.units {
+			if fu.end {
+				break
+			}
+
+			reps := fu.reps
+			if i == len(fs.units)-1 && !fu.setReps && fu.count > 0 && fs.size < f.size {
+				reps += (f.size - fs.size) / fu.count
+			}
+
+			for n := reps; n > 0; n-- {
+				for j, c := range fu.conversions {
+					switch {
+					case c.kind == convText && n == 1 && reps > 1 && j == len(fu.conversions)-1:
+						_, _ = f.WriteString(strings.TrimRightFunc(c.text, unicode.IsSpace))
+
+					case c.kind != convText && end >= 0 && addr >= end:
+						_, _ = f.WriteString(c.text + spaces(c.width()))
+
+					default:
+						_, _ = f.WriteString(c.format(addr, b[:min(c.size, len(b))], f.ByteOrder))
+					}
+
+					addr += int64(c.size)
+					b = b[min(c.size, len(b)):]
+				}
+			}
+		}
+	}
+
+	return f.Flush()
+}
+
+// FormatSqueeze writes a line containing a single '*' in place of identical consecutive blocks.
+func (f *layoutFormatter) FormatSqueeze() error {
+	_, err := f.WriteString("*\n")
+
+	return errors.Join(err, f.Flush())
+}
+
+// FormatEnd writes the offset of the end of the input with the format unit of %_A conversion.
+func (f *layoutFormatter) FormatEnd(off int64) error {
+	if f.end == nil || off == 0 {
+		return f.Flush()
+	}
+
+	for _, c := range f.end.conversions {
+		switch c.kind { //nolint:exhaustive
+		case convAddress:
+			_, _ = f.WriteString(c.format(off, nil, f.ByteOrder))
+		case convText:
+			_, _ = f.WriteString(c.text)
+		}
+	}
+
+	return f.Flush()
+}
+
+// format renders the conversion of the bytes at the address.
+func (c *conversion) format(addr int64, b []byte, order binary.ByteOrder) string {
+	switch c.kind {
+	case convText:
+		return c.text
+
+	case convAddress:
+		return c.text + fmt.Sprintf("%"+c.spec+string(c.verb), addr)
+
+	case convChar:
+		return c.text + c.formatString(string(b[:1]))
+
+	case convInt:
+		return c.text + fmt.Sprintf("%"+c.spec+string(c.verb), readInt(b, order))
+
+	case convUint:
+		return c.text + fmt.Sprintf("%"+c.spec+string(c.verb), readUint(b, order))
+
+	case convFloat:
+		return c.text + c.formatFloat(b, order)
+
+	case convString:
+		if i := strings.IndexByte(string(b), 0); i >= 0 {
+			b = b[:i]
+		}
+
+		return c.text + c.formatString(string(b))
+
+	case convEscapedChar:
+		return c.text + c.formatString(escapedChar(b[0]))
+
+	case convPrintableChar:
+		return c.text + c.formatString(string(printable(b[0])))
+
+	case convASCIIChar:
+		switch ch := b[0]; {
+		case ch < ' ':
+			return c.text + c.formatString(asciiNames[ch])
+		case ch == 0x7f:
+			return c.text + c.formatString("del")
+		case ch < 0x7f:
+			return c.text + c.formatString(string(ch))
+		default:
+			return c.text + fmt.Sprintf("%"+c.spec+"x", ch)
+		}
+	}
+
+	return c.text
+}
+
+func (c *conversion) formatFloat(b []byte, order binary.ByteOrder) string {
+	var v float64
+
+	if len(b) == fourBytes {
+		v = float64(math.Float32frombits(uint32(readUint(b, order)))) //nolint:gosec
+	} else {
+		v = math.Float64frombits(readUint(b, order))
+	}
+
+	upper := c.verb == 'E' || c.verb == 'G'
+
+	var special string
+
+	switch {
+	case math.IsInf(v, 1):
+		special = "inf"
+	case math.IsInf(v, -1):
+		special = "-inf"
+	case math.IsNaN(v):
+		special = "nan"
+	}
+
+	if special != "" {
+		if upper {
+			special = strings.ToUpper(special)
+		}
+
+		width := c.width()
+		if flags, _ := c.flags(); strings.Contains(flags, "-") {
+			width = -width
+		}
+
+		return fmt.Sprintf("%*s", width, special)
+	}
+
+	spec := c.spec
+	if (c.verb == 'g' || c.verb == 'G') && !c.hasPrec {
+		spec += ".6"
+	}
+
+	return fmt.Sprintf("%"+spec+string(c.verb), v)
+}
+
+// formatString renders a string with the width, precision and justification of the conversion.
+func (c *conversion) formatString(s string) string {
+	flags, rest := c.flags()
+	if strings.Contains(flags, "-") {
+		return fmt.Sprintf("%-"+rest+"s", s)
+	}
+
+	return fmt.Sprintf("%"+rest+"s", s)
+}
+
+// flags splits the flags from the width and precision of the conversion.
+func (c *conversion) flags() (flags, rest string) {
+	rest = strings.TrimLeft(c.spec, "#-+ 0")
+
+	return c.spec[:len(c.spec)-len(rest)], rest
+}
+
+// width returns the field width of the conversion.
+func (c *conversion) width() int {
+	_, rest := c.flags()
+	n, _ := strconv.Atoi(leadingDigits(rest))
+
+	return n
+}
+
+// escapedChar returns the character of a byte, with C escapes or three-digit octal for non-printable bytes.
+func escapedChar(c byte) string {
+	if i := strings.IndexByte("\x00\a\b\f\n\r\t\v", c); i >= 0 {
+		return `\` + string(`0abfnrtv`[i])
+	}
+
+	if p := printable(c); p == c {
+		return string(c)
+	}
+
+	return fmt.Sprintf("%03o", c)
+}
+
+var asciiNames = [...]string{
+	"nul", "soh", "stx", "etx", "eot", "enq", "ack", "bel", "bs", "ht", "lf", "vt", "ff", "cr", "so", "si",
+	"dle", "dc1", "dc2", "dc3", "dc4", "nak", "syn", "etb", "can", "em", "sub", "esc", "fs", "gs", "rs", "us",
+}
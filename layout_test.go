@@ -0,0 +1,144 @@
+package hexdump_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/flier/hexdump"
+)
+
+func ExampleParseLayout() {
+	l, _ := hexdump.ParseLayout(`"%07.7_Ax\n"`, `"%07.7_ax " 8/2 "%04x " "\n"`)
+
+	_ = hexdump.String("Hello, Gophers!\n", hexdump.Layout(l), hexdump.LittleEndian)
+	// Output:
+	// 0000000 6548 6c6c 2c6f 4720 706f 6568 7372 0a21
+	// 0000010
+}
+
+func ExampleReadFormats() {
+	formats, _ := hexdump.ReadFormats(strings.NewReader(`# hexdump -C
+"%08.8_Ax\n"
+"%08.8_ax  " 8/1 "%02x " "  " 8/1 "%02x "
+"  |" 16/1 "%_p" "|\n"
+`))
+
+	l, _ := hexdump.ParseLayout(formats...)
+
+	_ = hexdump.String("Hello, World!\n", hexdump.Layout(l))
+	// Output:
+	// 00000000  48 65 6c 6c 6f 2c 20 57  6f 72 6c 64 21 0a        |Hello, World!.|
+	// 0000000e
+}
+
+func TestLayout(t *testing.T) {
+	t.Parallel()
+
+	hello := "Hello, World!\n"
+
+	Convey("Given the format strings of hexdump", t, func() {
+		for _, tc := range []struct {
+			name    string
+			formats []string
+			input   string
+			opts    []hexdump.Option
+			want    string
+		}{
+			{
+				"canonical", []string{`"%08.8_Ax\n"`, `"%08.8_ax  " 8/1 "%02x " "  " 8/1 "%02x "`, `"  |" 16/1 "%_p" "|\n"`},
+				hello, nil,
+				"00000000  48 65 6c 6c 6f 2c 20 57  6f 72 6c 64 21 0a        |Hello, World!.|\n" +
+					"0000000e\n",
+			},
+			{
+				"two-byte hex", []string{`"%07.7_Ax\n"`, `"%07.7_ax " 8/2 "   %04x " "\n"`},
+				hello, []hexdump.Option{hexdump.LittleEndian},
+				"0000000    6548    6c6c    2c6f    5720    726f    646c    0a21        \n" +
+					"000000e\n",
+			},
+			{
+				"one-byte char", []string{`"%07.7_Ax\n"`, `"%07.7_ax " 16/1 "%3_c " "\n"`},
+				hello, nil,
+				"0000000   H   e   l   l   o   ,       W   o   r   l   d   !  \\n        \n" +
+					"000000e\n",
+			},
+			{
+				"one-byte octal", []string{`"%07.7_Ax\n"`, `"%07.7_ax " 16/1 "%03o " "\n"`},
+				"\x00\x01\xff", nil,
+				"0000000 000 001 377" + strings.Repeat(" ", 52) + "\n" +
+					"0000003\n",
+			},
+			{
+				"two-byte decimal squeezed", []string{`"%07.7_Ax\n"`, `"%07.7_ax " 8/2 "  %05u " "\n"`},
+				strings.Repeat("\x00", 64), nil,
+				"0000000   00000   00000   00000   00000   00000   00000   00000   00000\n" +
+					"*\n" +
+					"0000040\n",
+			},
+			{
+				"verbose", []string{`"%_ad: " 4/1 "%02x" "\n"`},
+				"\x00\x00\x00\x00\x00\x00\x00\x00", []hexdump.Option{hexdump.Verbose(true)},
+				"0: 00000000\n" +
+					"4: 00000000\n",
+			},
+			{
+				"skip", []string{`"%_ad: " 4/1 "%02x" "\n"`},
+				"0123456789", []hexdump.Option{hexdump.Skip(3)},
+				"3: 33343536\n" +
+					"7: 373839  \n",
+			},
+			{
+				"us ascii", []string{`"%06.6_ao "  12/1 "%3_u "`, `"\t" "%_p "`, `"\n"`},
+				"\x00\x01\x7f\x80AB\tz", nil,
+				"000000 nul soh del  80   A   B  ht   z                \t. . . . A B . z    \n",
+			},
+			{
+				"string", []string{`2/4 "%-6.4s|" "\n"`},
+				"abcdefgh", nil,
+				"abcd  |efgh  |\n",
+			},
+			{
+				"float", []string{`/4 "%8.2f" "/8 %g\n"`},
+				"\x00\x00\x80\x3f\x00\x00\x00\x00\x00\x00\xf0\x7f", []hexdump.Option{hexdump.LittleEndian},
+				"    1.00/8 inf\n",
+			},
+			{
+				"signed", []string{`4/1 "%4d" "\n"`, `2/2 "%+6i" "\n"`},
+				"\xff\x80\x7f\x00", []hexdump.Option{hexdump.BigEndian},
+				"  -1-128 127   0\n  -128+32512\n",
+			},
+		} {
+			Convey("When dump with the format strings of "+tc.name, func() {
+				l, err := hexdump.ParseLayout(tc.formats...)
+				So(err, ShouldBeNil)
+
+				var buf bytes.Buffer
+
+				So(hexdump.String(tc.input, append(tc.opts, hexdump.Layout(l), hexdump.Output(&buf))...), ShouldBeNil)
+				So(buf.String(), ShouldEqual, tc.want)
+			})
+		}
+	})
+
+	Convey("Given the bad format strings", t, func() {
+		for _, format := range []string{
+			`"%z"`,
+			`"%_a"`,
+			`"%s"`,
+			`/3 "%d"`,
+			`4/1 "%02x %02x"`,
+			`"%02x`,
+			`4 x "%02x"`,
+			`"text only"`,
+		} {
+			Convey("When parse the format string "+format, func() {
+				_, err := hexdump.ParseLayout(format)
+
+				So(err, ShouldWrap, hexdump.ErrSyntax)
+			})
+		}
+	})
+}
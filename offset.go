@@ -0,0 +1,118 @@
+package hexdump
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"strconv"
+	"strings"
+)
+
+//go:generate go tool stringer -type=OffsetRadix -linecomment
+
+// OffsetRadix is the radix of the offset column.
+type OffsetRadix int //nolint:recvcheck
+
+const (
+	RadixHex   OffsetRadix = iota // hex
+	RadixDec                      // dec
+	RadixOctal                    // octal
+	RadixNone                     // none
+)
+
+func (r OffsetRadix) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+// UnmarshalText parses the name of the radix, which can be abbreviated to x, d, o or n like od(1).
+func (r *OffsetRadix) UnmarshalText(text []byte) error {
+	for i := range len(_OffsetRadix_index) - 1 {
+		name := _OffsetRadix_name[_OffsetRadix_index[i]:_OffsetRadix_index[i+1]]
+
+		if strings.EqualFold(string(text), name) || string(text) == radixAbbrevs[i:i+1] {
+			*r = OffsetRadix(i)
+
+			return nil
+		}
+	}
+
+	return fmt.Errorf("offset radix %q, %w", text, os.ErrInvalid)
+}
+
+const radixAbbrevs = "xdon"
+
+// base returns the numeric base of the radix.
+func (r OffsetRadix) base() int {
+	switch r {
+	case RadixDec:
+		return 10
+	case RadixOctal:
+		return 8
+	default:
+		return 16
+	}
+}
+
+// DefaultOffsetWidth is the default minimum number of digits of the offset.
+const DefaultOffsetWidth = 8
+
+// OffsetFormat is the format of the offset column.
+type OffsetFormat struct {
+	Radix  OffsetRadix // The radix of the offset, the default is [RadixHex].
+	Width  int         // The minimum number of digits, the default is [DefaultOffsetWidth].
+	Upper  bool        // Display the hexadecimal digits in upper case.
+	Prefix string      // The prefix of the offset, like "0x".
+}
+
+// Format returns the text of the offset, which is empty for [RadixNone].
+func (o OffsetFormat) Format(off int64) string {
+	if o.Radix == RadixNone {
+		return ""
+	}
+
+	width := o.Width
+	if width <= 0 {
+		width = DefaultOffsetWidth
+	}
+
+	verb := "x"
+
+	switch {
+	case o.Radix == RadixDec:
+		verb = "d"
+	case o.Radix == RadixOctal:
+		verb = "o"
+	case o.Upper:
+		verb = "X"
+	}
+
+	return o.Prefix + fmt.Sprintf("%0*"+verb, width, off)
+}
+
+// fit returns the format with the width grown to display the end offset.
+func (o OffsetFormat) fit(end int64) OffsetFormat {
+	if o.Width <= 0 {
+		o.Width = DefaultOffsetWidth
+	}
+
+	o.Width = max(o.Width, len(strconv.FormatInt(end, o.Radix.base())))
+
+	return o
+}
+
+// parse parses the text of an offset.
+func (o OffsetFormat) parse(s string) (int64, error) {
+	return strconv.ParseInt(strings.TrimPrefix(s, o.Prefix), o.Radix.base(), 64)
+}
+
+// inputSize returns the size of a regular file, otherwise zero.
+func inputSize(r io.Reader) int64 {
+	if f, ok := r.(interface{ Stat() (fs.FileInfo, error) }); ok {
+		if fi, err := f.Stat(); err == nil && fi.Mode().IsRegular() {
+			return fi.Size()
+		}
+	}
+
+	return 0
+}
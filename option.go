@@ -1,9 +1,12 @@
 package hexdump
 
 import (
+	"cmp"
 	"encoding/binary"
 	"io"
 	"os"
+
+	"github.com/fatih/color"
 )
 
 // Option can be used to customize the behavior of the [Dumper].
@@ -17,13 +20,49 @@ var (
 	AlwaysColor = Color(ColorAlways) // Always color mode.
 	NeverColor  = Color(ColorNever)  // Never color mode.
 
-	Canonical     = Style(StyleCanonical)     // Canonical hex+ASCII display.
-	OneByteChar   = Style(StyleOneByteChar)   // One-byte character display.
-	OneByteHex    = Style(StyleOneByteHex)    // One-byte hex display.
-	OneByteOctal  = Style(StyleOneByteOctal)  // One-byte octal display.
-	TwoBytesDec   = Style(StyleTwoBytesDec)   // Two-byte decimal display.
-	TwoBytesHex   = Style(StyleTwoBytesHex)   // Two-byte hexadecimal display
-	TwoBytesOctal = Style(StyleTwoBytesOctal) // Wwo-byte octal display
+	Canonical           = Style(StyleCanonical)           // Canonical hex+ASCII display.
+	OneByteChar         = Style(StyleOneByteChar)         // One-byte character display.
+	OneByteHex          = Style(StyleOneByteHex)          // One-byte hex display.
+	OneByteOctal        = Style(StyleOneByteOctal)        // One-byte octal display.
+	OneByteDec          = Style(StyleOneByteDec)          // One-byte decimal display.
+	OneByteBinary       = Style(StyleOneByteBinary)       // One-byte binary display.
+	OneByteSignedDec    = Style(StyleOneByteSignedDec)    // One-byte signed decimal display.
+	TwoBytesDec         = Style(StyleTwoBytesDec)         // Two-byte decimal display.
+	TwoBytesSignedDec   = Style(StyleTwoBytesSignedDec)   // Two-byte signed decimal display.
+	TwoBytesHex         = Style(StyleTwoBytesHex)         // Two-byte hexadecimal display
+	TwoBytesOctal       = Style(StyleTwoBytesOctal)       // Two-byte octal display
+	FourBytesDec        = Style(StyleFourBytesDec)        // Four-byte decimal display.
+	FourBytesSignedDec  = Style(StyleFourBytesSignedDec)  // Four-byte signed decimal display.
+	FourBytesHex        = Style(StyleFourBytesHex)        // Four-byte hexadecimal display.
+	FourBytesOctal      = Style(StyleFourBytesOctal)      // Four-byte octal display.
+	EightBytesDec       = Style(StyleEightBytesDec)       // Eight-byte decimal display.
+	EightBytesSignedDec = Style(StyleEightBytesSignedDec) // Eight-byte signed decimal display.
+	EightBytesHex       = Style(StyleEightBytesHex)       // Eight-byte hexadecimal display.
+	EightBytesOctal     = Style(StyleEightBytesOctal)     // Eight-byte octal display.
+	Float16             = Style(StyleFloat16)             // Half precision floating-point display.
+	BFloat16            = Style(StyleBFloat16)            // Brain floating-point display.
+	Float32             = Style(StyleFloat32)             // Single precision floating-point display.
+	Float64             = Style(StyleFloat64)             // Double precision floating-point display.
+
+	Table   = Mode(ModeTable)   // Table of offset, content and chars.
+	Plain   = Mode(ModePlain)   // Continuous plain hex, like `xxd -p`.
+	Include = Mode(ModeInclude) // C include file, like `xxd -i`.
+	Go      = Mode(ModeGo)      // Go []byte literal.
+	Rust    = Mode(ModeRust)    // Rust [u8; N] or &[u8] literal.
+	Python  = Mode(ModePython)  // Python bytes literal.
+	Java    = Mode(ModeJava)    // Java byte[] literal.
+	IHex    = Mode(ModeIHex)    // Intel HEX records.
+	S19     = Mode(ModeS19)     // Motorola S-records with 16-bit addresses.
+	S28     = Mode(ModeS28)     // Motorola S-records with 24-bit addresses.
+	S37     = Mode(ModeS37)     // Motorola S-records with 32-bit addresses.
+
+	HexOffset   = Offset(OffsetFormat{Radix: RadixHex})   // Hexadecimal offset.
+	DecOffset   = Offset(OffsetFormat{Radix: RadixDec})   // Decimal offset.
+	OctalOffset = Offset(OffsetFormat{Radix: RadixOctal}) // Octal offset.
+	NoOffset    = Offset(OffsetFormat{Radix: RadixNone})  // No offset column.
+
+	Legend       = Labels(LabelLegend) // Display the labels of the highlighted ranges in a legend after the table.
+	InlineLabels = Labels(LabelInline) // Display the labels of the highlighted ranges at the end of the lines they start.
 
 	LittleEndian = ByteOrder(binary.LittleEndian) // Little-endian byte order.
 	BigEndian    = ByteOrder(binary.BigEndian)    // Big-endian byte order.
@@ -33,18 +72,21 @@ var (
 // The output stream, the default is [os.Stdout].
 func Output(w io.Writer) Option { return func(d *Dumper) { d.Output = w } }
 
-// The number of bytes per line, the default is 16.
+// The number of bytes per line, the default is 16 for table, 30 for plain hex and 12 for source code.
 func LineWidth(n int) Option { return func(d *Dumper) { d.LineWidth = n } }
 
 // The color mode of the line, the default is [ColorAuto].
 func Color(c ColorMode) Option { return func(d *Dumper) { d.Color = c } }
 
-// The color theme of the line, the default is [DefaultTheme].
+// The color theme of the line, the default is the theme of [ThemeEnv] if it is set, otherwise [DefaultTheme].
 func Theme(t *ColorTheme) Option { return func(d *Dumper) { d.Theme = t } }
 
 // The display style of the line, the default is [StyleCanonical].
 func Style(s DisplayStyle) Option { return func(d *Dumper) { d.Style = s } }
 
+// The display styles of the rows stacked beneath the offset of each line, only the first row has the char column.
+func Styles(s ...DisplayStyle) Option { return func(d *Dumper) { d.Styles = s } }
+
 // The byte order used to read the data group, the default is [binary.NativeEndian].
 func ByteOrder(b binary.ByteOrder) Option { return func(d *Dumper) { d.ByteOrder = b } }
 
@@ -57,6 +99,41 @@ func Skip(n int64) Option { return func(d *Dumper) { d.Skip = n } }
 // Interpret only length bytes of input.
 func Length(n int64) Option { return func(d *Dumper) { d.Length = n } }
 
+// The output mode, the default is [ModeTable].
+func Mode(m OutputMode) Option { return func(d *Dumper) { d.Mode = m } }
+
+// The name of the input, which is used to derive the variable name of source code and the S-record header.
+func Name(s string) Option { return func(d *Dumper) { d.Name = s } }
+
+// The format of the offset column, the width grows to display the end offset of the input if its size is known.
+func Offset(o OffsetFormat) Option { return func(d *Dumper) { d.Offset = o } }
+
+// The format of the cells in the content column and the delimiters of the char column.
+func Cells(c CellFormat) Option { return func(d *Dumper) { d.Cells = c } }
+
+// Highlight the bytes between the offsets [start, end) of the table with the color and label,
+// the color is [DefaultHighlight] if nil.
+func Highlight(start, end int64, c *color.Color, label string) Option {
+	return func(d *Dumper) {
+		d.Highlights = append(d.Highlights, HighlightRange{start, end, cmp.Or(c, DefaultHighlight), label})
+	}
+}
+
+// How the labels of the highlighted ranges are displayed, the default is [LabelNone].
+func Labels(m LabelMode) Option { return func(d *Dumper) { d.Labels = m } }
+
+// Display all input data, otherwise identical consecutive lines are replaced with a line containing a single '*'.
+func Verbose(v bool) Option { return func(d *Dumper) { d.Verbose = v } }
+
+// Align the inputs of a diff with an edit script, so the inserted and deleted bytes are displayed as gaps.
+func Align(v bool) Option { return func(d *Dumper) { d.Align = v } }
+
+// The format strings applied to each block of the input instead of the output mode.
+func Layout(l *FormatLayout) Option { return func(d *Dumper) { d.Layout = l } }
+
+// The custom formatter of the lines instead of the output mode and layout.
+func LineFormat(f LineFormatter) Option { return func(d *Dumper) { d.Formatter = f } }
+
 // Extract the range of input from start to end.
 func Range(start, end int64) Option {
 	if start > end {
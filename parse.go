@@ -0,0 +1,316 @@
+package hexdump
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// ErrSyntax indicates that a line of the dump can't be parsed.
+var ErrSyntax = errors.New("syntax error")
+
+var ansiEscape = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+
+// Parse returns a reader of the binary content parsed from the dump read from r.
+//
+// It reverses the output of [Dumper] with the display style, byte order, line width, offset format and start offset of the options.
+// The gap before a line is filled with zeros, and the lines replaced with '*' are filled with the last line.
+// The ANSI color codes in the dump are ignored.
+// The lines without offset column are assumed to follow each other, so the squeezed lines can't be restored.
+//
+// The one-byte character display can't distinguish unprintable bytes from spaces,
+// they are parsed as spaces, and the spaces at the beginning or end of a line are lost.
+func Parse(r io.Reader, x ...Option) io.Reader {
+	d := New(x...)
+	d.setDefaults()
+
+	p := &parser{
+		s:      bufio.NewScanner(r),
+		style:  d.Style,
+		order:  d.ByteOrder,
+		width:  d.LineWidth,
+		offset: d.Offset,
+		cells:  d.Cells,
+		off:    d.Start,
+	}
+
+	if sp, ok := d.Style.(StyleParser); ok {
+		p.sp = sp
+	} else {
+		p.err = fmt.Errorf("parse style %T, %w", d.Style, errors.ErrUnsupported)
+	}
+
+	return p
+}
+
+type parser struct {
+	s        *bufio.Scanner
+	sp       StyleParser
+	style    DisplayStyle
+	order    binary.ByteOrder
+	width    int
+	offset   OffsetFormat
+	cells    CellFormat
+	line     int    // The number of the current line.
+	off      int64  // The offset of the next byte.
+	last     []byte // The content of the last full line.
+	squeezed bool   // The lines after the last line are replaced with '*'.
+	fill     []byte // The pattern to fill the gap before the current line.
+	fillAt   int    // The position in the pattern.
+	fillN    int64  // The number of bytes to fill.
+	buf      []byte // The content of the current line.
+	err      error
+}
+
+// Read reads the parsed binary content into b.
+func (p *parser) Read(b []byte) (n int, err error) {
+	for p.fillN == 0 && len(p.buf) == 0 {
+		if p.err != nil {
+			return 0, p.err
+		}
+
+		p.err = p.next()
+	}
+
+	for n < len(b) && p.fillN > 0 {
+		chunk := p.fill[p.fillAt:]
+		if int64(len(chunk)) > p.fillN {
+			chunk = chunk[:p.fillN]
+		}
+
+		c := copy(b[n:], chunk)
+
+		n += c
+		p.fillN -= int64(c)
+		p.fillAt = (p.fillAt + c) % len(p.fill)
+	}
+
+	c := copy(b[n:], p.buf)
+	p.buf = p.buf[c:]
+
+	return n + c, nil
+}
+
+func (p *parser) next() error {
+	if !p.s.Scan() {
+		if err := p.s.Err(); err != nil {
+			return err
+		}
+
+		return io.EOF
+	}
+
+	p.line++
+
+	text := strings.TrimRight(ansiEscape.ReplaceAllString(p.s.Text(), ""), "\r")
+
+	switch strings.TrimSpace(text) {
+	case "":
+		return nil
+
+	case "*":
+		p.squeezed = true
+
+		return nil
+	}
+
+	if err := p.parseLine(text); err != nil {
+		return fmt.Errorf("line %d %w, %w", p.line, ErrSyntax, err)
+	}
+
+	return nil
+}
+
+func (p *parser) parseLine(text string) error {
+	off, rest, err := p.parseOffset(text)
+	if err != nil {
+		return err
+	}
+
+	content, chars := p.splitChars([]rune(rest))
+
+	skip, data, err := p.parseCells(p.splitCells(content), chars)
+	if err != nil {
+		return err
+	}
+
+	if p.squeezed && p.last != nil && off > p.off {
+		p.setFill(p.last, off-p.off)
+		p.off = off
+	}
+
+	p.squeezed = false
+
+	start := off + int64(skip)
+
+	switch {
+	case start < p.off:
+		return fmt.Errorf("offset %x before %x", start, p.off)
+
+	case p.fillN == 0:
+		p.setFill(make([]byte, p.width), start-p.off)
+
+	default:
+		data = append(make([]byte, start-p.off), data...)
+	}
+
+	if skip == 0 && len(data) == p.width {
+		p.last = data
+	} else {
+		p.last = nil
+	}
+
+	p.buf = data
+	p.off = start + int64(len(data))
+
+	return nil
+}
+
+// parseOffset parses the offset column of a line,
+// the line without offset column is assumed to follow the last line.
+func (p *parser) parseOffset(text string) (off int64, rest string, err error) {
+	if p.offset.Radix == RadixNone {
+		return p.off - p.off%int64(p.width), text, nil
+	}
+
+	field, rest, _ := strings.Cut(text, " ")
+
+	if off, err = p.offset.parse(field); err != nil {
+		return 0, "", fmt.Errorf("offset %q, %w", field, err)
+	}
+
+	return
+}
+
+func (p *parser) setFill(pattern []byte, n int64) {
+	p.fill, p.fillAt, p.fillN = pattern, 0, n
+}
+
+// splitChars splits the char column from the rest of the line.
+func (p *parser) splitChars(rest []rune) (content, chars []rune) {
+	left, right := p.cells.delims()
+	l, r := len([]rune(left)), len([]rune(right))
+	n, w := len(rest), p.width
+
+	if n < w+l+r+len("  ") {
+		return rest, nil
+	}
+
+	head, end := n-w-l-r-len("  "), n-r
+
+	if string(rest[end:]) == right && string(rest[head:head+len("  ")+l]) == "  "+left {
+		return rest[:head], rest[end-w : end]
+	}
+
+	return rest, nil
+}
+
+// splitCells splits the content column into cells.
+func (p *parser) splitCells(content []rune) (cells []string) {
+	w := p.style.CellWidth()
+	pos := len(" ")
+
+	for i := 0; pos < len(content); i++ {
+		if i > 0 {
+			pos += len([]rune(p.cells.separator(p.style, i*p.style.GroupSize())))
+		}
+
+		if pos >= len(content) {
+			return
+		}
+
+		cells = append(cells, string(content[pos:min(pos+w, len(content))]))
+		pos += w
+	}
+
+	return
+}
+
+// parseCells parses the cells of a line, returns the number of skipped bytes and the content of the line.
+func (p *parser) parseCells(cells []string, chars []rune) (skip int, data []byte, err error) {
+	blank := func(cell string) bool { return strings.TrimSpace(cell) == "" }
+
+	lo, hi := 0, len(cells)
+
+	for lo < hi && blank(cells[lo]) {
+		lo++
+	}
+
+	for hi > lo && blank(cells[hi-1]) {
+		hi--
+	}
+
+	if lo == hi {
+		return 0, nil, nil
+	}
+
+	size := p.style.GroupSize()
+
+	for _, cell := range cells[lo : hi-1] {
+		b, err := p.sp.ParseGroup(cell, size, p.order)
+		if err != nil {
+			return 0, nil, fmt.Errorf("cell %q, %w", cell, err)
+		}
+
+		data = append(data, b...)
+	}
+
+	last := cells[hi-1]
+
+	if skip, b, ok := p.matchLastCell(last, chars, lo*size, data); ok {
+		return skip, append(data, b...), nil
+	}
+
+	b, err := p.sp.ParseGroup(last, size, p.order)
+	if err != nil {
+		return 0, nil, fmt.Errorf("cell %q, %w", last, err)
+	}
+
+	return lo * size, append(data, b...), nil
+}
+
+// matchLastCell parses the last cell of a line, which may be rendered from a partial group,
+// returns the number of skipped bytes and the largest group that matches the char column.
+func (p *parser) matchLastCell(cell string, chars []rune, skip int, data []byte) (int, []byte, bool) {
+	if chars == nil {
+		return 0, nil, false
+	}
+
+	size := p.style.GroupSize()
+
+	for end := skip + size; skip < end && skip < len(chars); skip++ {
+		if strings.TrimSpace(string(chars[:skip])) != "" {
+			break
+		}
+
+		for n := size; n > 0; n-- {
+			b, err := p.sp.ParseGroup(cell, n, p.order)
+			if err == nil && matchChars(chars, skip, slices.Concat(data, b)) {
+				return skip, b, true
+			}
+		}
+	}
+
+	return 0, nil, false
+}
+
+func matchChars(chars []rune, pos int, b []byte) bool {
+	for i, c := range b {
+		if pos+i >= len(chars) || chars[pos+i] != rune(printable(c)) {
+			return false
+		}
+	}
+
+	for _, r := range chars[min(pos+len(b), len(chars)):] {
+		if r != ' ' {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,194 @@
+package hexdump_test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/flier/hexdump"
+)
+
+func ExampleParse() {
+	dump := `00000000  48 65 6c 6c 6f 2c 20 57  6f 72 6c 64 21           |Hello, World!   |`
+
+	_, _ = io.Copy(os.Stdout, hexdump.Parse(strings.NewReader(dump)))
+	// Output:
+	// Hello, World!
+}
+
+func ExampleParse_squeeze() {
+	dump := `00000000  00 00 00 00 00 00 00 00  00 00 00 00 00 00 00 00  |................|
+*
+00000030  48 65 6c 6c 6f 2c 20 57  6f 72 6c 64 21           |Hello, World!   |`
+
+	b, _ := io.ReadAll(hexdump.Parse(strings.NewReader(dump)))
+
+	fmt.Printf("%d %q", len(b), b[44:])
+	// Output:
+	// 61 "\x00\x00\x00\x00Hello, World!"
+}
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	styles := map[string]hexdump.DisplayStyle{
+		"binary":  hexdump.BinaryStyle{Nibbles: true, LSBFirst: true},
+		"payload": hexdump.FloatStyle{Kind: hexdump.KindFloat32, Payload: true},
+	}
+
+	for _, name := range hexdump.StyleNames() {
+		styles[name], _ = hexdump.LookupStyle(name)
+	}
+
+	Convey("Given some random binary content", t, func() {
+		r := rand.New(rand.NewPCG(1, 2)) //nolint:gosec
+
+		b := make([]byte, 300)
+		for i := range b {
+			b[i] = byte(r.UintN(256))
+		}
+
+		copy(b[64:], make([]byte, 80))
+
+		for name, style := range styles {
+			if name == "one-byte-char" || strings.Contains(name, "float") || name == "payload" {
+				continue
+			}
+
+			for i, opts := range [][]hexdump.Option{
+				{},
+				{hexdump.LineWidth(8), hexdump.BigEndian},
+				{hexdump.Skip(7), hexdump.Length(255)},
+				{hexdump.Start(0x1003), hexdump.AlwaysColor},
+				{hexdump.DecOffset, hexdump.Start(0x10000)},
+				{hexdump.Offset(hexdump.OffsetFormat{Upper: true, Prefix: "0x", Width: 4}), hexdump.Start(0xfff8)},
+				{hexdump.NoOffset, hexdump.Verbose(true)},
+				{hexdump.Cells(hexdump.CellFormat{Group: 4, Upper: true}), hexdump.LineWidth(24)},
+				{hexdump.Cells(hexdump.CellFormat{NoSep: true, NoDelims: true}), hexdump.Skip(3), hexdump.Length(200)},
+				{hexdump.Cells(hexdump.CellFormat{CellSep: ":", GroupSep: " - ", Delims: [2]string{"[", "]"}})},
+			} {
+				Convey(fmt.Sprintf("When dump it with the %s style and options #%d", name, i), func() {
+					var out strings.Builder
+
+					x := append([]hexdump.Option{hexdump.Style(style), hexdump.Output(&out)}, opts...)
+
+					So(hexdump.Bytes(b, x...), ShouldBeNil)
+
+					Convey("Then parse the dump should return the content", func() {
+						d := hexdump.New(x...)
+
+						got, err := io.ReadAll(hexdump.Parse(strings.NewReader(out.String()), x...))
+
+						So(err, ShouldBeNil)
+
+						want := b
+						if d.Skip > 0 {
+							want = append(make([]byte, d.Skip), b[d.Skip:d.Skip+d.Length]...)
+						}
+
+						So(got, ShouldResemble, want)
+					})
+				})
+			}
+		}
+	})
+
+	Convey("Given some float numbers", t, func() {
+		b := []byte{
+			0x00, 0x3c, 0x55, 0x35, 0x01, 0x00, 0x00, 0x7c, 0xff, 0xfb, 0x00, 0x80, 0x01, 0x7c, 0x00, 0x7e,
+			0xdb, 0x0f, 0x49, 0x40, 0x01, 0x00, 0xc0, 0x7f, 0x23, 0x01, 0x80, 0xff, 0x00, 0x00, 0x80, 0x7f,
+		}
+
+		for _, name := range []string{"float16", "bfloat16", "float32", "float64", "payload"} {
+			Convey("When dump them with the "+name+" style", func() {
+				var out strings.Builder
+
+				x := []hexdump.Option{hexdump.Style(styles[name]), hexdump.Output(&out), hexdump.LittleEndian}
+
+				So(hexdump.Bytes(b, x...), ShouldBeNil)
+
+				Convey("Then parse the dump should return the numbers", func() {
+					got, err := io.ReadAll(hexdump.Parse(strings.NewReader(out.String()), x...))
+
+					So(err, ShouldBeNil)
+
+					if name == "payload" {
+						So(got, ShouldResemble, b)
+					} else {
+						So(contentColumn(dumpString(got, x[0])), ShouldEqual, contentColumn(out.String()))
+					}
+				})
+			})
+		}
+	})
+
+	Convey("Given some float numbers followed by a partial group", t, func() {
+		b := []byte{0xdb, 0x0f, 0x49, 0x40, 0x01, 0x00, 0xc0, 0x7f, 0xde, 0xad, 0xbe}
+
+		Convey("When dump them with the payload style", func() {
+			var out strings.Builder
+
+			x := []hexdump.Option{hexdump.Style(styles["payload"]), hexdump.Output(&out), hexdump.LittleEndian}
+
+			So(hexdump.Bytes(b, x...), ShouldBeNil)
+			So(out.String(), ShouldContainSubstring, " deadbe ")
+
+			Convey("Then parse the dump should return the bytes of the partial group", func() {
+				got, err := io.ReadAll(hexdump.Parse(strings.NewReader(out.String()), x...))
+
+				So(err, ShouldBeNil)
+				So(got, ShouldResemble, b)
+			})
+		})
+	})
+
+	Convey("Given a text with printable characters", t, func() {
+		s := "Hello, World!\nHow are you?"
+
+		Convey("When dump it with the one-byte char style", func() {
+			var out strings.Builder
+
+			So(hexdump.String(s, hexdump.OneByteChar, hexdump.Output(&out)), ShouldBeNil)
+
+			Convey("Then parse the dump should replace unprintable bytes with spaces", func() {
+				got, err := io.ReadAll(hexdump.Parse(strings.NewReader(out.String()), hexdump.OneByteChar))
+
+				So(err, ShouldBeNil)
+				So(string(got), ShouldEqual, "Hello, World! How are you?")
+			})
+		})
+	})
+
+	Convey("Given an invalid dump", t, func() {
+		dump := "00000000  48 65 6c 6c 6f 2c 20 57  6f 72 6c 64 21           |Hello, World!   |\n00000010  zz\n"
+
+		Convey("When parse it", func() {
+			_, err := io.ReadAll(hexdump.Parse(strings.NewReader(dump)))
+
+			Convey("Then it should return a syntax error", func() {
+				So(err, ShouldWrap, hexdump.ErrSyntax)
+				So(err.Error(), ShouldContainSubstring, "line 2")
+			})
+		})
+	})
+}
+
+// contentColumn removes the char column, which may differ for the NaN values without payload.
+func contentColumn(s string) string {
+	return regexp.MustCompile(`(?m)  \|.*\|$`).ReplaceAllString(s, "")
+}
+
+func dumpString(b []byte, x ...hexdump.Option) string {
+	var out bytes.Buffer
+
+	_ = hexdump.Bytes(b, append(x, hexdump.Output(&out), hexdump.LittleEndian)...)
+
+	return out.String()
+}
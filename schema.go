@@ -0,0 +1,294 @@
+package hexdump
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// SchemaTag is the key of the struct tags of the schema.
+const SchemaTag = "hexdump"
+
+// SchemaField is a field of the binary content decoded with a schema.
+type SchemaField struct {
+	Name  string // The name of the field, nested fields are joined with dots.
+	Start int64  // The offset of the first byte of the field.
+	End   int64  // The offset after the last byte of the field.
+	Value any    // The decoded value of the field.
+}
+
+// Schema converts a byte slice into a readable ASCII table with the fields of the schema of the struct type T,
+// each field is highlighted, and its decoded value is displayed in a side panel of the line it starts.
+//
+// The schema is decoded from the bytes after the [Skip] option, and the fields are highlighted at their offsets in the table.
+//
+// See [DecodeSchema] for the struct tags of the schema.
+func Schema[T any](b []byte, x ...Option) error {
+	d := New(x...)
+
+	fields, err := DecodeSchema(reflect.TypeFor[T](), b[min(max(d.Skip, 0), int64(len(b))):])
+	if err != nil {
+		return err
+	}
+
+	base := d.Start + d.Skip
+	opts := []Option{InlineLabels}
+
+	for i, f := range fields {
+		opts = append(opts, Highlight(base+f.Start, base+f.End,
+			FieldColors[i%len(FieldColors)], f.Name+"="+formatValue(f.Value)))
+	}
+
+	return Bytes(b, append(opts, x...)...)
+}
+
+// DecodeSchema decodes the fields of the struct type from the bytes packed without padding.
+//
+// The fields are decoded in the order of the struct with the options of the `hexdump` tag separated by commas:
+//
+//   - "be" or "le" decodes the field in big-endian or little-endian byte order, the default is big-endian;
+//   - "len=N" is the number of elements of a slice or string field, which is a number or the name of an earlier field;
+//   - "-" skips the field.
+//
+// The fields can be integers, floating-point numbers, booleans, strings, and the arrays, slices and structs of them.
+func DecodeSchema(t reflect.Type, b []byte) ([]SchemaField, error) {
+	d := &schemaDecoder{b: b, values: make(map[string]int64)}
+
+	if err := d.decodeStruct("", t, binary.BigEndian); err != nil {
+		return nil, err
+	}
+
+	return d.fields, nil
+}
+
+type schemaDecoder struct {
+	b      []byte
+	off    int64
+	fields []SchemaField
+	values map[string]int64 // The integer values of the decoded fields.
+}
+
+func (d *schemaDecoder) decodeStruct(prefix string, t reflect.Type, order binary.ByteOrder) error {
+	if t.Kind() != reflect.Struct {
+		return fmt.Errorf("schema of %s, %w", t, ErrSyntax)
+	}
+
+	for i := range t.NumField() {
+		f := t.Field(i)
+		name := join(prefix, f.Name)
+
+		tag, err := parseSchemaTag(f.Tag.Get(SchemaTag), order)
+		if err != nil {
+			return fmt.Errorf("field %s, %w", name, err)
+		}
+
+		if tag.skip {
+			continue
+		}
+
+		n := -1
+
+		if tag.len != "" {
+			if n, err = d.length(prefix, tag.len); err != nil {
+				return fmt.Errorf("field %s, %w", name, err)
+			}
+		}
+
+		if err = d.decodeField(name, f.Type, tag.order, n); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// length returns the length of a field, which is a number or the value of an earlier field.
+func (d *schemaDecoder) length(prefix, s string) (int, error) {
+	if n, err := strconv.Atoi(s); err == nil && n >= 0 {
+		return n, nil
+	}
+
+	if v, ok := d.values[join(prefix, s)]; ok && v >= 0 && v <= math.MaxInt32 {
+		return int(v), nil
+	}
+
+	return 0, fmt.Errorf("length %q, %w", s, ErrSyntax)
+}
+
+// decodeField decodes a field of the type, n is the number of elements of a slice or string, or -1 if absent.
+func (d *schemaDecoder) decodeField(name string, t reflect.Type, order binary.ByteOrder, n int) error {
+	switch t.Kind() {
+	case reflect.Struct:
+		return d.decodeStruct(name, t, order)
+
+	case reflect.Slice, reflect.String:
+		if n < 0 {
+			return fmt.Errorf("field %s without length, %w", name, ErrSyntax)
+		}
+
+		elem := reflect.TypeFor[byte]()
+		if t.Kind() == reflect.Slice {
+			elem = t.Elem()
+		}
+
+		if size := packedSize(elem); size > 0 && uint64(n) > uint64(int64(len(d.b))-d.off)/uint64(size) {
+			return fmt.Errorf("field %s at %d, %w", name, d.off, io.ErrUnexpectedEOF)
+		}
+
+		v := reflect.MakeSlice(reflect.SliceOf(elem), n, n)
+
+		return d.decodeValue(name, v, order, func() any {
+			if t.Kind() == reflect.String {
+				return reflect.ValueOf(string(v.Bytes())).Convert(t).Interface()
+			}
+
+			return v.Convert(t).Interface()
+		})
+
+	default:
+		v := reflect.New(t).Elem()
+
+		return d.decodeValue(name, v, order, v.Interface)
+	}
+}
+
+// packedSize returns the number of bytes of a value of the type packed without padding.
+func packedSize(t reflect.Type) int {
+	if n := binary.Size(reflect.New(t).Elem().Interface()); n >= 0 {
+		return n
+	}
+
+	return int(t.Size())
+}
+
+// decodeValue decodes the elements of the value from the bytes, and adds a field of the value.
+func (d *schemaDecoder) decodeValue(name string, v reflect.Value, order binary.ByteOrder, value func() any) error {
+	start := d.off
+
+	if err := d.decode(v, order); err != nil {
+		return fmt.Errorf("field %s at %d, %w", name, start, err)
+	}
+
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		d.values[name] = v.Int()
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		d.values[name] = int64(min(v.Uint(), math.MaxInt64)) //nolint:gosec
+	}
+
+	d.fields = append(d.fields, SchemaField{name, start, d.off, value()})
+
+	return nil
+}
+
+// decode decodes a value of the fixed-size type or the slice of them.
+func (d *schemaDecoder) decode(v reflect.Value, order binary.ByteOrder) error {
+	switch v.Kind() {
+	case reflect.Array, reflect.Slice:
+		for i := range v.Len() {
+			if err := d.decode(v.Index(i), order); err != nil {
+				return err
+			}
+		}
+
+		return nil
+
+	case reflect.Struct:
+		for i := range v.NumField() {
+			if err := d.decode(v.Field(i), order); err != nil {
+				return err
+			}
+		}
+
+		return nil
+
+	case reflect.Bool, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Float32, reflect.Float64:
+		n := int64(v.Type().Size())
+		if d.off+n > int64(len(d.b)) {
+			return io.ErrUnexpectedEOF
+		}
+
+		b := d.b[d.off : d.off+n]
+		d.off += n
+
+		if !v.CanSet() {
+			return nil
+		}
+
+		switch v.Kind() {
+		case reflect.Bool:
+			v.SetBool(b[0] != 0)
+		case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			v.SetInt(readInt(b, order))
+		case reflect.Float32:
+			v.SetFloat(float64(math.Float32frombits(order.Uint32(b))))
+		case reflect.Float64:
+			v.SetFloat(math.Float64frombits(order.Uint64(b)))
+		default:
+			v.SetUint(readUint(b, order))
+		}
+
+		return nil
+
+	default:
+		return fmt.Errorf("type %s, %w", v.Type(), ErrSyntax)
+	}
+}
+
+type schemaTag struct {
+	order binary.ByteOrder
+	len   string
+	skip  bool
+}
+
+func parseSchemaTag(s string, order binary.ByteOrder) (tag schemaTag, err error) {
+	tag.order = order
+
+	for _, opt := range strings.Split(s, ",") {
+		switch key, value, _ := strings.Cut(strings.TrimSpace(opt), "="); key {
+		case "":
+		case "-":
+			tag.skip = true
+		case "be":
+			tag.order = binary.BigEndian
+		case "le":
+			tag.order = binary.LittleEndian
+		case "len":
+			tag.len = value
+		default:
+			return tag, fmt.Errorf("tag %q, %w", opt, ErrSyntax)
+		}
+	}
+
+	return
+}
+
+// maxValueWidth is the maximum number of runes of a value in the side panel.
+const maxValueWidth = 24
+
+// formatValue returns the text of a decoded value in the side panel.
+func formatValue(v any) string {
+	s := fmt.Sprint(v)
+
+	switch rv := reflect.ValueOf(v); {
+	case rv.Kind() == reflect.String:
+		s = strconv.Quote(rv.String())
+
+	case (rv.Kind() == reflect.Array || rv.Kind() == reflect.Slice) && rv.Type().Elem().Kind() == reflect.Uint8:
+		b := make([]byte, rv.Len())
+		reflect.Copy(reflect.ValueOf(b), rv)
+		s = strconv.Quote(string(b))
+	}
+
+	if r := []rune(s); len(r) > maxValueWidth {
+		s = string(r[:maxValueWidth-len("...")]) + "..."
+	}
+
+	return s
+}
@@ -0,0 +1,142 @@
+package hexdump_test
+
+import (
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/flier/hexdump"
+)
+
+func ExampleSchema() {
+	type Frame struct {
+		Magic   [2]byte
+		Version uint8
+		Flags   uint16 `hexdump:"le"`
+		Length  uint16 `hexdump:"be"`
+		Payload string `hexdump:"len=Length"`
+		CRC     uint32
+	}
+
+	_ = hexdump.Schema[Frame]([]byte("HX\x01\x02\x01\x00\x0dHello, World!\xde\xad\xbe\xef"))
+	// Output:
+	// 00000000  48 58 01 02 01 00 0d 48  65 6c 6c 6f 2c 20 57 6f  |HX.....Hello, Wo|  Magic="HX", Version=1, Flags=258, Length=13, Payload="Hello, World!"
+	// 00000010  72 6c 64 21 de ad be ef                           |rld!....        |  CRC=3735928559
+}
+
+func TestDecodeSchema(t *testing.T) {
+	t.Parallel()
+
+	type Point struct {
+		X, Y int16
+	}
+
+	type Message struct {
+		Kind   uint8
+		_      uint8 `hexdump:"-"`
+		Count  uint8
+		Points []Point `hexdump:"len=Count"`
+		Origin Point   `hexdump:"le"`
+		Scale  float32
+		Tags   [2]uint8
+		OK     bool
+	}
+
+	b := []byte{
+		7, 2,
+		0x00, 0x01, 0xff, 0xfe, 0x00, 0x03, 0x00, 0x04,
+		0x05, 0x00, 0x06, 0x00,
+		0x3f, 0xc0, 0x00, 0x00,
+		9, 10,
+		1,
+	}
+
+	Convey("Given a schema with nested and variable-length fields", t, func() {
+		Convey("When decode the bytes with the schema", func() {
+			fields, err := hexdump.DecodeSchema(reflect.TypeFor[Message](), b)
+
+			Convey("Then the fields should be decoded", func() {
+				So(err, ShouldBeNil)
+				So(fields, ShouldResemble, []hexdump.SchemaField{
+					{"Kind", 0, 1, uint8(7)},
+					{"Count", 1, 2, uint8(2)},
+					{"Points", 2, 10, []Point{{1, -2}, {3, 4}}},
+					{"Origin.X", 10, 12, int16(5)},
+					{"Origin.Y", 12, 14, int16(6)},
+					{"Scale", 14, 18, float32(1.5)},
+					{"Tags", 18, 20, [2]uint8{9, 10}},
+					{"OK", 20, 21, true},
+				})
+			})
+		})
+
+		Convey("When decode the truncated bytes", func() {
+			_, err := hexdump.DecodeSchema(reflect.TypeFor[Message](), b[:12])
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldWrap, io.ErrUnexpectedEOF)
+			})
+		})
+
+		Convey("When decode a length larger than the remaining bytes", func() {
+			_, err := hexdump.DecodeSchema(reflect.TypeFor[struct {
+				N    uint32
+				Data []uint64 `hexdump:"len=N"`
+			}](), []byte{0x7f, 0xff, 0xff, 0xff})
+
+			Convey("Then it should fail before allocating the field", func() {
+				So(err, ShouldWrap, io.ErrUnexpectedEOF)
+			})
+		})
+
+		Convey("When decode with a bad schema", func() {
+			_, err := hexdump.DecodeSchema(reflect.TypeFor[struct {
+				Data []byte `hexdump:"len=Size"`
+			}](), b)
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldWrap, hexdump.ErrSyntax)
+			})
+		})
+	})
+}
+
+func TestSchemaOffset(t *testing.T) {
+	t.Parallel()
+
+	type Pair struct {
+		A, B uint16
+	}
+
+	Convey("Given the bytes of a schema", t, func() {
+		for _, tc := range []struct {
+			name string
+			b    []byte
+			opts []hexdump.Option
+		}{
+			{"start", []byte{1, 2, 3, 4}, []hexdump.Option{hexdump.Start(2)}},
+			{"skip", []byte{9, 9, 1, 2, 3, 4}, []hexdump.Option{hexdump.Skip(2)}},
+			{"start and skip", []byte{9, 9, 1, 2, 3, 4}, []hexdump.Option{hexdump.Start(0x10), hexdump.Skip(2)}},
+		} {
+			Convey("When dump them with the "+tc.name+" offset", func() {
+				var b strings.Builder
+
+				hi := hexdump.FieldColors[0]
+
+				err := hexdump.Schema[Pair](tc.b,
+					append(tc.opts, hexdump.AlwaysColor, hexdump.Output(&b))...)
+
+				Convey("Then the fields should be highlighted and labeled at their bytes", func() {
+					So(err, ShouldBeNil)
+					So(b.String(), ShouldContainSubstring, hi.Sprint("01"))
+					So(b.String(), ShouldNotContainSubstring, hi.Sprint("09"))
+					So(b.String(), ShouldContainSubstring, hi.Sprint("A=258"))
+					So(b.String(), ShouldContainSubstring, hexdump.FieldColors[1].Sprint("B=772"))
+				})
+			})
+		}
+	})
+}
@@ -0,0 +1,170 @@
+package hexdump
+
+import (
+	"bufio"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	srecMaxCount   = 0xff     // The maximum byte count of a record.
+	srecMaxCount16 = 0xffff   // The maximum record count of a S5 record.
+	srecMaxCount24 = 0xffffff // The maximum record count of a S6 record.
+)
+
+// srecEmitter writes the binary content as Motorola S-records.
+type srecEmitter struct {
+	w     *bufio.Writer
+	addr  int   // The number of address bytes of the data records: 2 for S19, 3 for S28 and 4 for S37.
+	entry int64 // The start address of the execution.
+	name  string
+	n     int // The number of data records.
+}
+
+func (e *srecEmitter) FormatLine(off int64, _ int, buf []byte) error {
+	if off < 0 || off+int64(len(buf)) > 1<<(8*e.addr) {
+		return fmt.Errorf("address %#x, %w", off, ErrAddress)
+	}
+
+	if e.n == 0 {
+		e.header()
+	}
+
+	for len(buf) > 0 {
+		n := min(len(buf), srecMaxCount-e.addr-1)
+
+		e.record('0'+byte(e.addr)-1, off, buf[:n])
+		e.n++
+
+		off += int64(n)
+		buf = buf[n:]
+	}
+
+	return e.w.Flush()
+}
+
+func (e *srecEmitter) FormatEnd(int64) error {
+	if e.n == 0 {
+		e.header()
+	}
+
+	if e.n <= srecMaxCount16 {
+		e.record('5', int64(e.n), nil)
+	} else if e.n <= srecMaxCount24 {
+		e.record('6', int64(e.n), nil)
+	}
+
+	e.record('9'-byte(e.addr)+2, e.entry, nil)
+
+	return e.w.Flush()
+}
+
+// header writes the S0 record of the name, which is cut to the maximum data of a record.
+func (e *srecEmitter) header() {
+	name := e.name[:min(len(e.name), srecMaxCount-srecAddressSize('0')-1)]
+
+	e.record('0', 0, []byte(name))
+}
+
+// record writes a record of the type with the address and data.
+func (e *srecEmitter) record(typ byte, addr int64, data []byte) {
+	size := srecAddressSize(typ)
+
+	b := make([]byte, 0, 1+size+len(data)+1)
+	b = append(b, byte(size+len(data)+1))
+
+	for i := size - 1; i >= 0; i-- {
+		b = append(b, byte(addr>>(8*i)))
+	}
+
+	b = append(b, data...)
+	b = append(b, ^sum(b))
+
+	_, _ = e.w.WriteString("S" + string(typ) + strings.ToUpper(hex.EncodeToString(b)) + "\n")
+}
+
+// srecAddressSize returns the number of address bytes of the record type.
+func srecAddressSize(typ byte) int {
+	switch typ {
+	case '2', '6', '8':
+		return 3
+	case '3', '7':
+		return 4
+	default:
+		return 2
+	}
+}
+
+// DecodeSRec decodes the Motorola S-records read from r into a sparse memory image.
+func DecodeSRec(r io.Reader) (*Image, error) {
+	var m Image
+
+	s := bufio.NewScanner(r)
+
+	for n := 1; s.Scan(); n++ {
+		line := strings.TrimSpace(s.Text())
+		if line == "" {
+			continue
+		}
+
+		if len(line) < 2 || line[0] != 'S' {
+			return nil, fmt.Errorf("line %d record type, %w", n, ErrSyntax)
+		}
+
+		typ := line[1]
+
+		rec, err := decodeRecord(line, line[:2])
+		if err != nil {
+			return nil, fmt.Errorf("line %d, %w", n, err)
+		}
+
+		size := srecAddressSize(typ)
+
+		if len(rec) < size+2 || int(rec[0]) != len(rec)-1 {
+			return nil, fmt.Errorf("line %d record length, %w", n, ErrSyntax)
+		}
+
+		if sum(rec) != 0xff {
+			return nil, fmt.Errorf("line %d, %w", n, ErrChecksum)
+		}
+
+		var addr int64
+
+		for _, c := range rec[1 : 1+size] {
+			addr = addr<<8 | int64(c)
+		}
+
+		data := rec[1+size : len(rec)-1]
+
+		switch typ {
+		case '0':
+			m.Header = append(m.Header, data...)
+
+		case '1', '2', '3':
+			m.add(addr, data)
+
+		case '5', '6':
+			// The record count is only informative.
+
+		case '7', '8', '9':
+			m.Entry = addr
+
+			m.normalize()
+
+			return &m, nil
+
+		default:
+			return nil, fmt.Errorf("line %d record type %c, %w", n, typ, ErrSyntax)
+		}
+	}
+
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+
+	m.normalize()
+
+	return &m, nil
+}
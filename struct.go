@@ -0,0 +1,110 @@
+package hexdump
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/fatih/color"
+)
+
+// FieldColors are the colors of the fields highlighted by [Fields] in turn.
+var FieldColors = []*color.Color{
+	color.New(color.FgCyan),
+	color.New(color.FgGreen),
+	color.New(color.FgYellow),
+	color.New(color.FgBlue),
+	color.New(color.FgMagenta),
+	color.New(color.FgRed),
+}
+
+// PaddingColor is the color of the alignment padding bytes highlighted by [Fields].
+var PaddingColor = color.New(color.FgHiBlack, color.CrossedOut)
+
+// PaddingLabel is the label of the alignment padding bytes highlighted by [Fields].
+const PaddingLabel = "padding"
+
+// Struct converts the contents of the struct pointed to by 'p' into a readable ASCII table,
+// each field is highlighted and labeled with its name and type in a legend, and the padding bytes are marked.
+func Struct[T any](p *T, x ...Option) (err error) {
+	opts := append([]Option{Legend}, x...)
+	opts = append(opts, Fields(reflect.TypeFor[T]()))
+
+	return Deref(p, opts...)
+}
+
+// Fields highlights the fields of the struct type at the start offset of the table,
+// the nested structs and the arrays of structs are walked into their fields,
+// and the alignment padding bytes between and after the fields are marked with [PaddingColor].
+//
+// The ranges are relative to the start offset, so the option should follow the [Start] option.
+func Fields(t reflect.Type) Option {
+	return func(d *Dumper) {
+		w := &fieldWalker{start: d.Start}
+		w.walk("", t, 0)
+
+		d.Highlights = append(d.Highlights, w.ranges...)
+	}
+}
+
+// fieldWalker walks the fields of a type and collects the highlighted ranges.
+type fieldWalker struct {
+	start  int64
+	ranges []HighlightRange
+	fields int
+}
+
+func (w *fieldWalker) walk(name string, t reflect.Type, off uintptr) {
+	switch {
+	case t.Kind() == reflect.Struct && t.NumField() > 0:
+		end := off
+
+		for i := range t.NumField() {
+			f := t.Field(i)
+			if f.Type.Size() == 0 {
+				continue
+			}
+
+			w.pad(end, off+f.Offset)
+			w.walk(join(name, f.Name), f.Type, off+f.Offset)
+
+			end = off + f.Offset + f.Type.Size()
+		}
+
+		w.pad(end, off+t.Size())
+
+	case t.Kind() == reflect.Array && t.Elem().Kind() == reflect.Struct:
+		for i := range t.Len() {
+			w.walk(fmt.Sprintf("%s[%d]", name, i), t.Elem(), off+uintptr(i)*t.Elem().Size())
+		}
+
+	case t.Size() > 0:
+		c := FieldColors[w.fields%len(FieldColors)]
+		w.fields++
+
+		label := t.String()
+		if name != "" {
+			label = name + " " + label
+		}
+
+		w.add(off, off+t.Size(), c, label)
+	}
+}
+
+// pad adds the padding bytes between the offsets.
+func (w *fieldWalker) pad(start, end uintptr) {
+	if start < end {
+		w.add(start, end, PaddingColor, PaddingLabel)
+	}
+}
+
+func (w *fieldWalker) add(start, end uintptr, c *color.Color, label string) {
+	w.ranges = append(w.ranges, HighlightRange{w.start + int64(start), w.start + int64(end), c, label})
+}
+
+func join(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+
+	return prefix + "." + name
+}
@@ -0,0 +1,73 @@
+package hexdump_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/flier/hexdump"
+)
+
+func ExampleStruct() {
+	type Header struct {
+		Kind  uint8
+		Flags uint16
+		Size  uint32
+	}
+
+	type Frame struct {
+		Header
+		Points [2]struct{ X, Y int16 }
+		Name   [3]byte
+		ID     uint64
+	}
+
+	_ = hexdump.Struct(&Frame{
+		Header: Header{Kind: 1, Flags: 0x0203, Size: 0x04050607},
+		Points: [2]struct{ X, Y int16 }{{1, 2}, {3, 4}},
+		Name:   [3]byte{'a', 'b', 'c'},
+		ID:     0x0102030405060708,
+	}, hexdump.LittleEndian)
+	// Output:
+	// 00000000  01 00 03 02 07 06 05 04  01 00 02 00 03 00 04 00  |................|
+	// 00000010  61 62 63 00 00 00 00 00  08 07 06 05 04 03 02 01  |abc.............|
+	// 00000000-00000001  Header.Kind uint8
+	// 00000001-00000002  padding
+	// 00000002-00000004  Header.Flags uint16
+	// 00000004-00000008  Header.Size uint32
+	// 00000008-0000000a  Points[0].X int16
+	// 0000000a-0000000c  Points[0].Y int16
+	// 0000000c-0000000e  Points[1].X int16
+	// 0000000e-00000010  Points[1].Y int16
+	// 00000010-00000013  Name [3]uint8
+	// 00000013-00000018  padding
+	// 00000018-00000020  ID uint64
+}
+
+func TestFields(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given a struct with trailing padding", t, func() {
+		type S struct {
+			A uint32
+			B uint8
+		}
+
+		Convey("When dump it with the fields at a start offset", func() {
+			var b strings.Builder
+
+			_ = hexdump.Bytes(make([]byte, 8), hexdump.Start(0x10), hexdump.Fields(reflect.TypeFor[S]()),
+				hexdump.Legend, hexdump.Output(&b))
+
+			Convey("Then the fields and the trailing padding should be in the legend", func() {
+				So(b.String(), ShouldEndWith, `
+00000010-00000014  A uint32
+00000014-00000015  B uint8
+00000015-00000018  padding
+`)
+			})
+		})
+	})
+}
@@ -2,106 +2,304 @@ package hexdump
 
 import (
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"iter"
+	"maps"
 	"slices"
+	"strconv"
+	"strings"
+	"sync"
 	"unicode"
 )
 
-// The display style of binary content.
-type DisplayStyle int
+// DisplayStyle renders groups of bytes as the cells of the content column.
+type DisplayStyle interface {
+	// GroupSize returns the number of bytes rendered in one cell.
+	GroupSize() int
+
+	// CellWidth returns the width of a rendered cell.
+	CellWidth() int
+
+	// FormatGroup renders a group of bytes as a cell.
+	//
+	// The group may be shorter than [DisplayStyle.GroupSize] at the end of the input.
+	FormatGroup(b []byte, order binary.ByteOrder) string
+
+	// PaddingCell returns the text of a cell without content.
+	PaddingCell() string
+}
+
+// StyleParser is implemented by the display styles whose cells can be parsed back into bytes.
+type StyleParser interface {
+	// ParseGroup parses a cell rendered by [DisplayStyle.FormatGroup] from a group of n bytes.
+	ParseGroup(cell string, n int, order binary.ByteOrder) ([]byte, error)
+}
+
+// GroupStyle is a [DisplayStyle] which renders each group of bytes with a function.
+type GroupStyle struct {
+	Size   int                                                              // The number of bytes rendered in one cell.
+	Width  int                                                              // The width of a rendered cell.
+	Render func(b []byte, order binary.ByteOrder) string                    // Render a group of bytes as a cell.
+	Parse  func(cell string, n int, order binary.ByteOrder) ([]byte, error) // Parse a cell back into a group of n bytes.
+}
+
+// GroupSize returns the number of bytes rendered in one cell.
+func (s *GroupStyle) GroupSize() int { return s.Size }
+
+// CellWidth returns the width of a rendered cell.
+func (s *GroupStyle) CellWidth() int { return s.Width }
+
+// FormatGroup renders a group of bytes as a cell.
+func (s *GroupStyle) FormatGroup(b []byte, order binary.ByteOrder) string { return s.Render(b, order) }
+
+// PaddingCell returns the text of a cell without content.
+func (s *GroupStyle) PaddingCell() string { return spaces(s.Width) }
+
+// ParseGroup parses a cell back into a group of n bytes.
+func (s *GroupStyle) ParseGroup(cell string, n int, order binary.ByteOrder) ([]byte, error) {
+	if s.Parse == nil {
+		return nil, fmt.Errorf("parse cell %q, %w", cell, errors.ErrUnsupported)
+	}
+
+	return s.Parse(cell, n, order)
+}
 
 const (
-	StyleCanonical     DisplayStyle = iota // Canonical hex+ASCII display.
-	StyleOneByteChar                       // One-byte character display.
-	StyleOneByteHex                        // One-byte hex display.
-	StyleOneByteOctal                      // One-byte octal display.
-	StyleTwoBytesDec                       // Two-byte decimal display.
-	StyleTwoBytesHex                       // Two-byte hexadecimal display
-	StyleTwoBytesOctal                     // Wwo-byte octal display
+	twoBytes   = 2
+	fourBytes  = 4
+	eightBytes = 8
 )
 
-func (s DisplayStyle) formatLine(width, skip int, buf []byte, order binary.ByteOrder) []string {
-	return slices.Concat(
-		s.padding(max(skip, 0)),
-		slices.Collect(s.formatGroups(buf, order)),
-		s.padding(max(width-skip-len(buf), 0)))
+var (
+	StyleCanonical           DisplayStyle = &GroupStyle{1, 2, formatByte("%02x"), parseUint(16)}                  // Canonical hex+ASCII display.
+	StyleOneByteChar         DisplayStyle = &GroupStyle{1, 3, formatChar, parseChar}                              // One-byte character display.
+	StyleOneByteHex          DisplayStyle = &GroupStyle{1, 2, formatByte("%02x"), parseUint(16)}                  // One-byte hex display.
+	StyleOneByteOctal        DisplayStyle = &GroupStyle{1, 3, formatByte("%03o"), parseUint(8)}                   // One-byte octal display.
+	StyleOneByteDec          DisplayStyle = &GroupStyle{1, 3, formatUint("%03d"), parseUint(10)}                  // One-byte decimal display.
+	StyleOneByteSignedDec    DisplayStyle = &GroupStyle{1, 4, formatInt("%4d"), parseInt(10)}                     // One-byte signed decimal display.
+	StyleTwoBytesDec         DisplayStyle = &GroupStyle{twoBytes, 7, formatUint("  %05d"), parseUint(10)}         // Two-byte decimal display.
+	StyleTwoBytesSignedDec   DisplayStyle = &GroupStyle{twoBytes, 7, formatInt("%7d"), parseInt(10)}              // Two-byte signed decimal display.
+	StyleTwoBytesHex         DisplayStyle = &GroupStyle{twoBytes, 7, formatUint("   %04x"), parseUint(16)}        // Two-byte hexadecimal display
+	StyleTwoBytesOctal       DisplayStyle = &GroupStyle{twoBytes, 7, formatUint(" %06o"), parseUint(8)}           // Two-byte octal display
+	StyleFourBytesDec        DisplayStyle = &GroupStyle{fourBytes, 11, formatUint(" %010d"), parseUint(10)}       // Four-byte decimal display.
+	StyleFourBytesSignedDec  DisplayStyle = &GroupStyle{fourBytes, 11, formatInt("%11d"), parseInt(10)}           // Four-byte signed decimal display.
+	StyleFourBytesHex        DisplayStyle = &GroupStyle{fourBytes, 11, formatUint("   %08x"), parseUint(16)}      // Four-byte hexadecimal display.
+	StyleFourBytesOctal      DisplayStyle = &GroupStyle{fourBytes, 11, formatUint("%011o"), parseUint(8)}         // Four-byte octal display.
+	StyleEightBytesDec       DisplayStyle = &GroupStyle{eightBytes, 22, formatUint("  %020d"), parseUint(10)}     // Eight-byte decimal display.
+	StyleEightBytesSignedDec DisplayStyle = &GroupStyle{eightBytes, 22, formatInt("%22d"), parseInt(10)}          // Eight-byte signed decimal display.
+	StyleEightBytesHex       DisplayStyle = &GroupStyle{eightBytes, 22, formatUint("      %016x"), parseUint(16)} // Eight-byte hexadecimal display.
+	StyleEightBytesOctal     DisplayStyle = &GroupStyle{eightBytes, 22, formatUint("%022o"), parseUint(8)}        // Eight-byte octal display.
+)
+
+func formatByte(format string) func([]byte, binary.ByteOrder) string {
+	return func(b []byte, _ binary.ByteOrder) string {
+		return fmt.Sprintf(format, b[0])
+	}
 }
 
-const twoBytes = 2
+func formatChar(b []byte, _ binary.ByteOrder) string {
+	if unicode.IsPrint(rune(b[0])) {
+		return fmt.Sprintf("  %c", b[0])
+	}
+
+	return spaces(3)
+}
 
-func (s DisplayStyle) padding(n int) []string {
-	switch s {
-	case StyleCanonical, StyleOneByteHex:
-		return slices.Repeat([]string{"  "}, n)
+func parseChar(cell string, _ int, _ binary.ByteOrder) ([]byte, error) {
+	r := []rune(cell)
 
-	case StyleOneByteChar, StyleOneByteOctal:
-		return slices.Repeat([]string{"   "}, n)
+	switch {
+	case strings.TrimSpace(cell) == "":
+		return []byte{' '}, nil
 
-	case StyleTwoBytesHex, StyleTwoBytesOctal, StyleTwoBytesDec:
-		return slices.Repeat([]string{"       "}, n/twoBytes)
+	case len(r) == 0 || r[len(r)-1] > unicode.MaxLatin1:
+		return nil, fmt.Errorf("char %q, %w", cell, strconv.ErrSyntax)
 
 	default:
-		return []string{}
+		return []byte{byte(r[len(r)-1])}, nil
+	}
+}
+
+func formatUint(format string) func([]byte, binary.ByteOrder) string {
+	return func(b []byte, order binary.ByteOrder) string {
+		return fmt.Sprintf(format, readUint(b, order))
+	}
+}
+
+func formatInt(format string) func([]byte, binary.ByteOrder) string {
+	return func(b []byte, order binary.ByteOrder) string {
+		return fmt.Sprintf(format, readInt(b, order))
+	}
+}
+
+func parseUint(base int) func(string, int, binary.ByteOrder) ([]byte, error) {
+	return func(cell string, n int, order binary.ByteOrder) ([]byte, error) {
+		v, err := strconv.ParseUint(strings.TrimSpace(cell), base, 8*n)
+		if err != nil {
+			return nil, err
+		}
+
+		return putUint(v, n, order), nil
+	}
+}
+
+func parseInt(base int) func(string, int, binary.ByteOrder) ([]byte, error) {
+	return func(cell string, n int, order binary.ByteOrder) ([]byte, error) {
+		v, err := strconv.ParseInt(strings.TrimSpace(cell), base, 8*n)
+		if err != nil {
+			return nil, err
+		}
+
+		return putUint(uint64(v), n, order), nil //nolint:gosec
+	}
+}
+
+// readUint reads an unsigned integer from a group of bytes,
+// a partial group is read as an integer of its own size.
+func readUint(b []byte, order binary.ByteOrder) (v uint64) {
+	if isBigEndian(order) {
+		for _, c := range b {
+			v = v<<8 | uint64(c)
+		}
+	} else {
+		for i, c := range b {
+			v |= uint64(c) << (8 * i)
+		}
+	}
+
+	return
+}
+
+// readInt reads a signed integer from a group of bytes,
+// a partial group is sign-extended from its own size.
+func readInt(b []byte, order binary.ByteOrder) int64 {
+	shift := 64 - 8*len(b)
+
+	return int64(readUint(b, order)<<shift) >> shift //nolint:gosec
+}
+
+// putUint writes an unsigned integer as a group of n bytes.
+func putUint(v uint64, n int, order binary.ByteOrder) []byte {
+	b := make([]byte, n)
+
+	for i := range n {
+		if isBigEndian(order) {
+			b[n-1-i] = byte(v >> (8 * i))
+		} else {
+			b[i] = byte(v >> (8 * i))
+		}
 	}
+
+	return b
+}
+
+var byteOrderProbe = [2]byte{0, 1}
+
+func isBigEndian(order binary.ByteOrder) bool {
+	return order.Uint16(byteOrderProbe[:]) == 1
+}
+
+func formatLine(s DisplayStyle, width, skip int, buf []byte, order binary.ByteOrder) []string {
+	return slices.Concat(
+		padding(s, max(skip, 0)),
+		slices.Collect(formatGroups(s, buf, order)),
+		padding(s, max(width-skip-len(buf), 0)))
+}
+
+func padding(s DisplayStyle, n int) []string {
+	return slices.Repeat([]string{s.PaddingCell()}, n/s.GroupSize())
 }
 
-func (s DisplayStyle) formatGroups(b []byte, order binary.ByteOrder) iter.Seq[string] {
+func formatGroups(s DisplayStyle, b []byte, order binary.ByteOrder) iter.Seq[string] {
 	return func(yield func(s string) bool) {
+		size := s.GroupSize()
 		rest := b
 
 		for len(rest) > 0 {
-			var str string
+			n := min(size, len(rest))
 
-			str, rest = s.formatGroup(rest, order)
-
-			if !yield(str) {
+			if !yield(s.FormatGroup(rest[:n], order)) {
 				return
 			}
+
+			rest = rest[n:]
 		}
 	}
 }
 
-func (s DisplayStyle) formatGroup(b []byte, order binary.ByteOrder) (text string, rest []byte) {
-	switch s {
-	case StyleCanonical, StyleOneByteHex, StyleOneByteOctal:
-		return s.formatValue(b[0]), b[1:]
-
-	case StyleOneByteChar:
-		if unicode.IsPrint(rune(b[0])) {
-			return s.formatValue(b[0]), b[1:]
-		}
+// cell is a rendered group of bytes which ends at the boundary of its group in the line, even if the group is partial.
+type cell struct {
+	text string
+	end  int
+}
 
-		return s.padding(1)[0], b[1:]
+// formatCells renders the groups of bytes of a line, the first skip bytes of the line are absent.
+func formatCells(s DisplayStyle, skip int, buf []byte, order binary.ByteOrder) iter.Seq[cell] {
+	return func(yield func(cell) bool) {
+		end := skip
 
-	case StyleTwoBytesDec, StyleTwoBytesHex, StyleTwoBytesOctal:
-		var v uint16
+		for text := range formatGroups(s, buf, order) {
+			end += s.GroupSize()
 
-		if len(b) == 1 {
-			v, rest = uint16(b[0]), nil
-		} else {
-			v, rest = order.Uint16(b[:2]), b[2:]
+			if !yield(cell{text, end}) {
+				return
+			}
 		}
+	}
+}
 
-		text = s.formatValue(v)
+var (
+	stylesMu sync.RWMutex
+	styles   = map[string]DisplayStyle{
+		"canonical":              StyleCanonical,
+		"one-byte-char":          StyleOneByteChar,
+		"one-byte-hex":           StyleOneByteHex,
+		"one-byte-octal":         StyleOneByteOctal,
+		"one-byte-dec":           StyleOneByteDec,
+		"one-byte-signed-dec":    StyleOneByteSignedDec,
+		"one-byte-binary":        StyleOneByteBinary,
+		"two-bytes-dec":          StyleTwoBytesDec,
+		"two-bytes-signed-dec":   StyleTwoBytesSignedDec,
+		"two-bytes-hex":          StyleTwoBytesHex,
+		"two-bytes-octal":        StyleTwoBytesOctal,
+		"four-bytes-dec":         StyleFourBytesDec,
+		"four-bytes-signed-dec":  StyleFourBytesSignedDec,
+		"four-bytes-hex":         StyleFourBytesHex,
+		"four-bytes-octal":       StyleFourBytesOctal,
+		"eight-bytes-dec":        StyleEightBytesDec,
+		"eight-bytes-signed-dec": StyleEightBytesSignedDec,
+		"eight-bytes-hex":        StyleEightBytesHex,
+		"eight-bytes-octal":      StyleEightBytesOctal,
+		"float16":                StyleFloat16,
+		"bfloat16":               StyleBFloat16,
+		"float32":                StyleFloat32,
+		"float64":                StyleFloat64,
+	}
+)
 
-		return
+// RegisterStyle registers the display style with the name, replacing any style registered with the same name.
+func RegisterStyle(name string, s DisplayStyle) {
+	stylesMu.Lock()
+	defer stylesMu.Unlock()
 
-	default:
-		panic(s)
-	}
+	styles[name] = s
 }
 
-func (s DisplayStyle) formatValue(v ...any) string {
-	return fmt.Sprintf(formatValues[s], v...)
+// LookupStyle returns the display style registered with the name.
+func LookupStyle(name string) (s DisplayStyle, ok bool) {
+	stylesMu.RLock()
+	defer stylesMu.RUnlock()
+
+	s, ok = styles[name]
+
+	return
 }
 
-var formatValues = map[DisplayStyle]string{
-	StyleCanonical:     "%02x",
-	StyleOneByteChar:   "  %c",
-	StyleOneByteHex:    "%02x",
-	StyleOneByteOctal:  "%03o",
-	StyleTwoBytesDec:   "  %05d",
-	StyleTwoBytesHex:   "   %04x",
-	StyleTwoBytesOctal: " %06o",
+// StyleNames returns the sorted names of the registered display styles.
+func StyleNames() []string {
+	stylesMu.RLock()
+	defer stylesMu.RUnlock()
+
+	return slices.Sorted(maps.Keys(styles))
 }
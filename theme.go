@@ -0,0 +1,325 @@
+package hexdump
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/fatih/color"
+)
+
+// ThemeEnv is the environment variable of the default color theme,
+// which is the name of a registered theme or the colors of the theme like "offset=2:null=38;5;244:high=#cb4b16".
+const ThemeEnv = "HEXDUMP_COLORS"
+
+// colorNames are the names of the colors and attributes in the color specs.
+var colorNames = map[string]color.Attribute{
+	"reset":      color.Reset,
+	"bold":       color.Bold,
+	"faint":      color.Faint,
+	"italic":     color.Italic,
+	"underline":  color.Underline,
+	"blink":      color.BlinkSlow,
+	"reverse":    color.ReverseVideo,
+	"black":      color.FgBlack,
+	"red":        color.FgRed,
+	"green":      color.FgGreen,
+	"yellow":     color.FgYellow,
+	"blue":       color.FgBlue,
+	"magenta":    color.FgMagenta,
+	"cyan":       color.FgCyan,
+	"white":      color.FgWhite,
+	"hi-black":   color.FgHiBlack,
+	"hi-red":     color.FgHiRed,
+	"hi-green":   color.FgHiGreen,
+	"hi-yellow":  color.FgHiYellow,
+	"hi-blue":    color.FgHiBlue,
+	"hi-magenta": color.FgHiMagenta,
+	"hi-cyan":    color.FgHiCyan,
+	"hi-white":   color.FgHiWhite,
+}
+
+const (
+	bgOffset  = color.BgBlack - color.FgBlack // The offset of the background colors from the foreground colors.
+	extended  = 38                            // The SGR parameter of the extended foreground color.
+	palette   = 5                             // The 256-color palette of the extended color.
+	trueColor = 2                             // The 24-bit RGB of the extended color.
+)
+
+// ParseColor parses a color spec, which is a SGR sequence like "1;38;5;208",
+// or a list of words separated by spaces, each word is one of
+//
+//   - the name of an attribute: reset, bold, faint, italic, underline, blink or reverse;
+//   - the name of a color: black, red, green, yellow, blue, magenta, cyan, white, or with the prefix "hi-";
+//   - a 256-color like "color208";
+//   - a 24-bit RGB color like "#cb4b16".
+//
+// The color words with the prefix "bg-" are applied to the background.
+func ParseColor(spec string) (*color.Color, error) {
+	spec = strings.TrimSpace(spec)
+
+	if spec != "" && strings.Trim(spec, "0123456789;") == "" {
+		c := color.New()
+
+		for _, s := range strings.Split(spec, ";") {
+			n, err := strconv.Atoi(s)
+			if err != nil {
+				return nil, fmt.Errorf("color %q, %w", spec, ErrSyntax)
+			}
+
+			c.Add(color.Attribute(n))
+		}
+
+		return c, nil
+	}
+
+	c := color.New()
+
+	for _, word := range strings.Fields(spec) {
+		attrs, err := parseColorWord(word)
+		if err != nil {
+			return nil, err
+		}
+
+		c.Add(attrs...)
+	}
+
+	return c, nil
+}
+
+func parseColorWord(word string) ([]color.Attribute, error) {
+	name, bg := strings.CutPrefix(strings.ToLower(word), "bg-")
+
+	var shift color.Attribute
+	if bg {
+		shift = bgOffset
+	}
+
+	if a, ok := colorNames[name]; ok && (!bg || a >= color.FgBlack) {
+		return []color.Attribute{a + shift}, nil
+	}
+
+	if s, ok := strings.CutPrefix(name, "color"); ok {
+		if n, err := strconv.ParseUint(s, 10, 8); err == nil {
+			return []color.Attribute{extended + shift, palette, color.Attribute(n)}, nil
+		}
+	}
+
+	if s, ok := strings.CutPrefix(name, "#"); ok && len(s) == len("rrggbb") {
+		if n, err := strconv.ParseUint(s, 16, 24); err == nil {
+			return []color.Attribute{
+				extended + shift, trueColor,
+				color.Attribute(n >> 16), color.Attribute(n >> 8 & 0xff), color.Attribute(n & 0xff),
+			}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("color %q, %w", word, ErrSyntax)
+}
+
+// themeColor returns the color of the key in the theme.
+func themeColor(t *ColorTheme, key string) (**color.Color, bool) {
+	switch strings.ToLower(key) {
+	case "offset":
+		return &t.Offset, true
+	case "content":
+		return &t.Content, true
+	case "chars":
+		return &t.Chars, true
+	case "null":
+		return &t.Null, true
+	case "printable":
+		return &t.Printable, true
+	case "whitespace":
+		return &t.Whitespace, true
+	case "control":
+		return &t.Control, true
+	case "high":
+		return &t.High, true
+	case "old":
+		return &t.Old, true
+	case "new":
+		return &t.New, true
+	default:
+		return nil, false
+	}
+}
+
+// setColor sets the color of the key in the theme, the byte class of the color "none" has no color.
+func (t *ColorTheme) setColor(key, spec string) error {
+	p, ok := themeColor(t, key)
+	if !ok {
+		return fmt.Errorf("theme key %q, %w", key, ErrSyntax)
+	}
+
+	if strings.EqualFold(strings.TrimSpace(spec), "none") {
+		if p == &t.Offset || p == &t.Content || p == &t.Chars {
+			*p = color.New(color.Reset)
+		} else {
+			*p = nil
+		}
+
+		return nil
+	}
+
+	c, err := ParseColor(spec)
+	if err != nil {
+		return fmt.Errorf("theme key %q, %w", key, err)
+	}
+
+	*p = c
+
+	return nil
+}
+
+// ParseTheme parses the colors of a theme separated by colons like "offset=2:null=38;5;244:high=#cb4b16",
+// the keys are offset, content, chars, null, printable, whitespace, control, high, old and new,
+// and the colors of the keys absent are the colors of [DefaultTheme].
+func ParseTheme(s string) (*ColorTheme, error) {
+	t := DefaultTheme
+
+	for _, field := range strings.Split(s, ":") {
+		if strings.TrimSpace(field) == "" {
+			continue
+		}
+
+		key, spec, ok := strings.Cut(field, "=")
+		if !ok {
+			return nil, fmt.Errorf("theme field %q, %w", field, ErrSyntax)
+		}
+
+		if err := t.setColor(strings.TrimSpace(key), spec); err != nil {
+			return nil, err
+		}
+	}
+
+	return &t, nil
+}
+
+// ReadTheme reads a theme file, which is a JSON object of the keys and colors,
+// or the lines of "key = color" like an INI file, where the lines starting with '#' or ';' are comments.
+//
+// The keys and colors are the same as [ParseTheme].
+func ReadTheme(r io.Reader) (*ColorTheme, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	t := DefaultTheme
+
+	if bytes.HasPrefix(bytes.TrimSpace(b), []byte("{")) {
+		var colors map[string]string
+
+		if err = json.Unmarshal(b, &colors); err != nil {
+			return nil, fmt.Errorf("theme, %w", err)
+		}
+
+		for _, key := range slices.Sorted(maps.Keys(colors)) {
+			if err = t.setColor(key, colors[key]); err != nil {
+				return nil, err
+			}
+		}
+
+		return &t, nil
+	}
+
+	s := bufio.NewScanner(bytes.NewReader(b))
+
+	for n := 1; s.Scan(); n++ {
+		line := strings.TrimSpace(s.Text())
+
+		if line == "" || line[0] == '#' || line[0] == ';' || line[0] == '[' {
+			continue
+		}
+
+		key, spec, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("line %d %w, %q", n, ErrSyntax, line)
+		}
+
+		if err = t.setColor(strings.TrimSpace(key), strings.Trim(strings.TrimSpace(spec), `"'`)); err != nil {
+			return nil, fmt.Errorf("line %d, %w", n, err)
+		}
+	}
+
+	return &t, s.Err()
+}
+
+// envTheme returns the theme of [ThemeEnv], or nil if it is not set or invalid.
+func envTheme() *ColorTheme {
+	s := os.Getenv(ThemeEnv)
+	if s == "" {
+		return nil
+	}
+
+	if t, ok := LookupTheme(s); ok {
+		return t
+	}
+
+	t, err := ParseTheme(s)
+	if err != nil {
+		return nil
+	}
+
+	return t
+}
+
+func mustParseTheme(s string) *ColorTheme {
+	t, err := ParseTheme(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return t
+}
+
+var (
+	themesMu sync.RWMutex
+	themes   = map[string]*ColorTheme{
+		"default":    &DefaultTheme,
+		"byte-class": &ByteClassTheme,
+		"light": mustParseTheme("offset=color244:content=reset:chars=reset:" +
+			"null=color250:printable=color25:whitespace=color28:control=color90:high=color130"),
+		"dark": mustParseTheme("offset=color242:content=reset:chars=reset:" +
+			"null=color240:printable=color117:whitespace=color114:control=color177:high=color215"),
+		"solarized": mustParseTheme("offset=#586e75:content=#839496:chars=#839496:" +
+			"null=#586e75:printable=#268bd2:whitespace=#859900:control=#d33682:high=#cb4b16"),
+		"high-contrast": mustParseTheme("offset=bold hi-white:content=hi-white:chars=hi-white:" +
+			"null=reverse:printable=bold hi-white:whitespace=bold hi-green:control=bold hi-magenta:high=bold hi-yellow"),
+	}
+)
+
+// RegisterTheme registers the color theme with the name, replacing any theme registered with the same name.
+func RegisterTheme(name string, t *ColorTheme) {
+	themesMu.Lock()
+	defer themesMu.Unlock()
+
+	themes[name] = t
+}
+
+// LookupTheme returns the color theme registered with the name.
+func LookupTheme(name string) (t *ColorTheme, ok bool) {
+	themesMu.RLock()
+	defer themesMu.RUnlock()
+
+	t, ok = themes[name]
+
+	return
+}
+
+// ThemeNames returns the sorted names of the registered color themes.
+func ThemeNames() []string {
+	themesMu.RLock()
+	defer themesMu.RUnlock()
+
+	return slices.Sorted(maps.Keys(themes))
+}
@@ -0,0 +1,137 @@
+package hexdump_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/fatih/color"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/flier/hexdump"
+)
+
+func ExampleParseTheme() {
+	t, _ := hexdump.ParseTheme("offset=2:null=38;5;244:high=bold #cb4b16")
+
+	fmt.Println(t.Offset.Equals(color.New(color.Faint)))
+	fmt.Println(t.High.Equals(color.New(color.Bold).AddRGB(203, 75, 22)))
+	// Output:
+	// true
+	// true
+}
+
+func ExampleThemeNames() {
+	fmt.Println(hexdump.ThemeNames())
+	// Output:
+	// [byte-class dark default high-contrast light solarized]
+}
+
+func TestTheme(t *testing.T) {
+	t.Parallel()
+
+	sgr := func(seq string) *color.Color {
+		c, err := hexdump.ParseColor(seq)
+		if err != nil {
+			panic(err)
+		}
+
+		return c
+	}
+
+	Convey("Given some color specs", t, func() {
+		for spec, want := range map[string]string{
+			"1;36":                  "1;36",
+			"bold cyan":             "1;36",
+			"hi-black bg-blue":      "90;44",
+			"color208 bg-color17":   "38;5;208;48;5;17",
+			"#268BD2 bg-#002b36":    "38;2;38;139;210;48;2;0;43;54",
+			"italic bg-hi-white":    "3;107",
+			"  underline   reverse": "4;7",
+		} {
+			Convey("When parse the color "+spec, func() {
+				c, err := hexdump.ParseColor(spec)
+
+				Convey("Then the color should have the SGR sequence", func() {
+					So(err, ShouldBeNil)
+					So(c.Equals(sgr(want)), ShouldBeTrue)
+				})
+			})
+		}
+
+		for _, spec := range []string{"purple", "color256", "#12345", "bg-bold", "1;x"} {
+			Convey("When parse the bad color "+spec, func() {
+				_, err := hexdump.ParseColor(spec)
+
+				Convey("Then it should fail", func() {
+					So(err, ShouldWrap, hexdump.ErrSyntax)
+				})
+			})
+		}
+	})
+
+	Convey("Given some theme files", t, func() {
+		for name, file := range map[string]string{
+			"json": `{"offset": "faint", "printable": "#268bd2", "Null": "none", "high": "color208"}`,
+			"ini": `# solarized printable
+[colors]
+offset = faint
+printable = "#268bd2"
+; no null
+null = none
+high=color208
+`,
+		} {
+			Convey("When read the "+name+" theme", func() {
+				theme, err := hexdump.ReadTheme(strings.NewReader(file))
+
+				Convey("Then the theme should have the colors", func() {
+					So(err, ShouldBeNil)
+					So(theme.Offset.Equals(sgr("2")), ShouldBeTrue)
+					So(theme.Content, ShouldEqual, hexdump.DefaultTheme.Content)
+					So(theme.Printable.Equals(sgr("38;2;38;139;210")), ShouldBeTrue)
+					So(theme.High.Equals(sgr("38;5;208")), ShouldBeTrue)
+					So(theme.Null, ShouldBeNil)
+					So(theme.Control, ShouldBeNil)
+				})
+			})
+		}
+
+		Convey("When read a theme with an unknown key", func() {
+			_, err := hexdump.ReadTheme(strings.NewReader("offset = faint\nborder = red\n"))
+
+			Convey("Then it should fail with the line", func() {
+				So(err, ShouldWrap, hexdump.ErrSyntax)
+				So(err.Error(), ShouldStartWith, "line 2")
+			})
+		})
+	})
+}
+
+func TestThemeEnv(t *testing.T) {
+	Convey("Given the theme in the environment variable", t, func() {
+		t.Setenv(hexdump.ThemeEnv, "null=red")
+
+		Convey("When dump some bytes without theme", func() {
+			var b strings.Builder
+
+			_ = hexdump.String("\x00", hexdump.AlwaysColor, hexdump.Output(&b))
+
+			Convey("Then the theme of the environment variable should be used", func() {
+				So(b.String(), ShouldContainSubstring, "\x1b[31m00\x1b[0m")
+			})
+		})
+
+		Convey("When the environment variable is a theme name", func() {
+			t.Setenv(hexdump.ThemeEnv, "byte-class")
+
+			var b strings.Builder
+
+			_ = hexdump.String("\x00", hexdump.AlwaysColor, hexdump.Output(&b))
+
+			Convey("Then the registered theme should be used", func() {
+				So(b.String(), ShouldContainSubstring, hexdump.ByteClassTheme.Null.Sprint("00"))
+			})
+		})
+	})
+}